@@ -0,0 +1,27 @@
+resource "okta_app_oauth" "test" {
+  label          = "testAcc_replace_with_uuid"
+  type           = "web"
+  grant_types    = ["authorization_code"]
+  redirect_uris  = ["http://d.com/"]
+  response_types = ["code"]
+}
+
+data "okta_app_signon_policy" "test" {
+  app_id = okta_app_oauth.test.id
+}
+
+resource "okta_device_assurance_policy" "test" {
+  name = "testAcc_replace_with_uuid"
+
+  macos {
+    os_version           = "12.4.5"
+    disk_encryption_type = ["ALL_INTERNAL_VOLUMES"]
+  }
+}
+
+resource "okta_app_signon_policy_rule" "test" {
+  policy_id                  = data.okta_app_signon_policy.test.id
+  name                       = "testAcc_replace_with_uuid"
+  device_is_registered       = true
+  device_assurances_included = [okta_device_assurance_policy.test.id]
+}
@@ -0,0 +1,10 @@
+resource "okta_device_assurance_policy" "test" {
+  name = "testAcc_replace_with_uuid"
+
+  android {
+    os_version           = "12"
+    disk_encryption_type = ["FULL", "USER"]
+    screen_lock_type     = ["BIOMETRIC"]
+    jailbreak            = false
+  }
+}
@@ -0,0 +1,11 @@
+resource "okta_device_assurance_policy" "test" {
+  name = "testAcc_replace_with_uuid_updated"
+
+  android {
+    os_version              = "13"
+    disk_encryption_type    = ["FULL"]
+    screen_lock_type        = ["PASSCODE", "BIOMETRIC"]
+    secure_hardware_present = true
+    jailbreak               = false
+  }
+}
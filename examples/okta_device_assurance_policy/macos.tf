@@ -0,0 +1,10 @@
+resource "okta_device_assurance_policy" "test" {
+  name = "testAcc_replace_with_uuid"
+
+  macos {
+    os_version              = "12.4.5"
+    disk_encryption_type    = ["ALL_INTERNAL_VOLUMES"]
+    screen_lock_type        = ["PASSCODE"]
+    secure_hardware_present = true
+  }
+}
@@ -0,0 +1,40 @@
+resource "okta_group" "test" {
+  name        = "testAcc_replace_with_uuid"
+  description = "testing, testing"
+}
+
+resource "okta_group" "owner" {
+  name        = "testAcc_owner_replace_with_uuid"
+  description = "owning group"
+}
+
+resource "okta_user" "test1" {
+  first_name = "TestAcc1"
+  last_name  = "Smith"
+  login      = "testAcc1-replace_with_uuid@example.com"
+  email      = "testAcc1-replace_with_uuid@example.com"
+}
+
+resource "okta_user" "test2" {
+  first_name = "TestAcc2"
+  last_name  = "Brando"
+  login      = "testAcc2-replace_with_uuid@example.com"
+  email      = "testAcc2-replace_with_uuid@example.com"
+}
+
+resource "okta_group_owners" "test" {
+  group_id = okta_group.test.id
+
+  owner {
+    id   = okta_user.test1.id
+    type = "USER"
+  }
+  owner {
+    id   = okta_group.owner.id
+    type = "GROUP"
+  }
+}
+
+data "okta_group_owners" "test" {
+  group_id = okta_group_owners.test.group_id
+}
@@ -0,0 +1,11 @@
+resource "okta_log_stream" "test" {
+  name   = "testAcc_replace_with_uuid_updated"
+  type   = "aws_eventbridge"
+  status = "INACTIVE"
+
+  settings {
+    account_id        = "123456789012"
+    event_source_name = "testAcc_replace_with_uuid"
+    region            = "us-east-1"
+  }
+}
@@ -0,0 +1,10 @@
+resource "okta_log_stream" "test" {
+  name = "testAcc_replace_with_uuid"
+  type = "splunk_cloud_logstreaming"
+
+  settings {
+    edition = "aws"
+    host    = "acme.splunkcloud.com"
+    token   = "YOUR_HEC_TOKEN"
+  }
+}
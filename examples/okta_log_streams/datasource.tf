@@ -0,0 +1,16 @@
+resource "okta_log_stream" "test" {
+  name = "testAcc_replace_with_uuid"
+  type = "aws_eventbridge"
+
+  settings {
+    account_id        = "123456789012"
+    event_source_name = "testAcc_replace_with_uuid"
+    region            = "us-east-1"
+  }
+}
+
+data "okta_log_streams" "test" {
+  type = "aws_eventbridge"
+
+  depends_on = [okta_log_stream.test]
+}
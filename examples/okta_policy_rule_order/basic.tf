@@ -0,0 +1,37 @@
+data "okta_group" "all" {
+  name = "Everyone"
+}
+
+resource "okta_policy_signon" "test" {
+  name            = "testAcc_replace_with_uuid"
+  status          = "ACTIVE"
+  description     = "Terraform Acceptance Test SignOn Policy"
+  groups_included = [data.okta_group.all.id]
+}
+
+resource "okta_policy_rule_signon" "one" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_one_replace_with_uuid"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_signon" "two" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_two_replace_with_uuid"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_signon" "three" {
+  policy_id = okta_policy_signon.test.id
+  name      = "testAcc_three_replace_with_uuid"
+  status    = "ACTIVE"
+}
+
+resource "okta_policy_rule_order" "test" {
+  policy_id = okta_policy_signon.test.id
+  rule_ids = [
+    okta_policy_rule_signon.one.id,
+    okta_policy_rule_signon.two.id,
+    okta_policy_rule_signon.three.id
+  ]
+}
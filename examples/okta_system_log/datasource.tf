@@ -0,0 +1,9 @@
+resource "okta_group" "test" {
+  name = "testAcc_replace_with_uuid"
+}
+
+data "okta_system_log" "test" {
+  filter     = "eventType eq \"group.lifecycle.create\" and target.id eq \"${okta_group.test.id}\""
+  sort_order = "DESCENDING"
+  limit      = 1
+}
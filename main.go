@@ -2,12 +2,62 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/okta/terraform-provider-okta/okta"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: okta.Provider,
-	})
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(export(os.Args[2:]))
+	}
+	// the SDK and framework providers are served as one, see okta.ProviderServer
+	providerServer, err := okta.ProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = tf5server.Serve("registry.terraform.io/okta/okta", providerServer)
+	okta.Shutdown()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// export writes the configuration and import blocks of an existing org, the
+// provider is configured with its environment variables e.g. OKTA_ORG_NAME and
+// OKTA_API_TOKEN.
+func export(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [options]\n\n"+
+			"Writes the Terraform configuration and import blocks of the objects of the Okta org\n"+
+			"the OKTA_* environment variables point at.\n\nOptions:\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	dir := flags.String("dir", ".", "directory to write the .tf files to")
+	types := flags.String("types", "", "comma separated resource types to export, all of "+
+		strings.Join(okta.ExportableResourceTypes(), ", ")+" by default")
+	_ = flags.Parse(args)
+
+	opts := okta.ExportOptions{Dir: *dir}
+	if *types != "" {
+		opts.Types = strings.Split(*types, ",")
+	}
+	err := okta.Export(context.Background(), opts)
+	okta.Shutdown()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+		return 1
+	}
+	if *types == "" {
+		fmt.Fprintf(os.Stderr, "warning: the objects of these resource types weren't exported, the exporter doesn't support them: %s\n",
+			strings.Join(okta.UnexportableResourceTypes(), ", "))
+	}
+	return 0
 }
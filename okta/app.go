@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -342,7 +341,7 @@ func updateAppByID(ctx context.Context, id string, m interface{}, app okta.App)
 	return suppressErrorOn404(resp, err)
 }
 
-func handleAppGroups(ctx context.Context, id string, d *schema.ResourceData, client *okta.Client) []func() error {
+func handleAppGroups(ctx context.Context, id string, d *schema.ResourceData, client *okta.Client) []setChangeJob {
 	if !d.HasChange("groups") {
 		return nil
 	}
@@ -350,7 +349,7 @@ func handleAppGroups(ctx context.Context, id string, d *schema.ResourceData, cli
 	if d.Get("skip_groups").(bool) {
 		return nil
 	}
-	var asyncActionList []func() error
+	var jobs []setChangeJob
 
 	oldGs, newGs := d.GetChange("groups")
 	oldSet := oldGs.(*schema.Set)
@@ -360,19 +359,32 @@ func handleAppGroups(ctx context.Context, id string, d *schema.ResourceData, cli
 
 	for i := range groupsToAdd {
 		gID := groupsToAdd[i]
-		asyncActionList = append(asyncActionList, func() error {
-			_, resp, err := client.Application.CreateApplicationGroupAssignment(ctx, id,
-				gID, okta.ApplicationGroupAssignment{})
-			return responseErr(resp, err)
+		jobs = append(jobs, setChangeJob{
+			job: job{
+				name: fmt.Sprintf("assign group (%s) to application (%s)", gID, id),
+				run: func(ctx context.Context) error {
+					_, resp, err := client.Application.CreateApplicationGroupAssignment(ctx, id,
+						gID, okta.ApplicationGroupAssignment{})
+					return responseErr(resp, err)
+				},
+			},
+			value: gID,
+			add:   true,
 		})
 	}
 	for i := range groupsToRemove {
 		gID := groupsToRemove[i]
-		asyncActionList = append(asyncActionList, func() error {
-			return suppressErrorOn404(client.Application.DeleteApplicationGroupAssignment(ctx, id, gID))
+		jobs = append(jobs, setChangeJob{
+			job: job{
+				name: fmt.Sprintf("unassign group (%s) from application (%s)", gID, id),
+				run: func(ctx context.Context) error {
+					return suppressErrorOn404(client.Application.DeleteApplicationGroupAssignment(ctx, id, gID))
+				},
+			},
+			value: gID,
 		})
 	}
-	return asyncActionList
+	return jobs
 }
 
 func listApplicationGroupAssignments(ctx context.Context, client *okta.Client, id string) ([]*okta.ApplicationGroupAssignment, *okta.Response, error) {
@@ -412,19 +424,26 @@ func shouldUpdateUser(userList []*okta.AppUser, id, username string) bool {
 	return false
 }
 
-// Handles the assigning of groups and users to Applications. Does so asynchronously.
+// Handles the assigning of groups and users to Applications. Does so
+// concurrently, when some of the assignments fail the groups and users are set
+// to what actually got assigned.
 func handleAppGroupsAndUsers(ctx context.Context, id string, d *schema.ResourceData, m interface{}) error {
-	var wg sync.WaitGroup
-	resultChan := make(chan []*result, 1)
 	client := getOktaClientFromMetadata(m)
 
-	groupHandlers := handleAppGroups(ctx, id, d, client)
-	userHandlers := handleAppUsers(ctx, id, d, client)
-	con := getParallelismFromMetadata(m)
-	promiseAll(con, &wg, resultChan, append(groupHandlers, userHandlers...)...)
-	wg.Wait()
-
-	return getPromiseError(<-resultChan, "failed to associate user or groups with application")
+	groupJobs := handleAppGroups(ctx, id, d, client)
+	userJobs, err := handleAppUsers(ctx, id, d, client)
+	if err != nil {
+		return err
+	}
+	jobs := setChangeJobsToJobs(append(groupJobs, userJobs...))
+	results := runJobs(ctx, getParallelismFromMetadata(m), jobs)
+	if len(groupJobs) > 0 {
+		setAfterJobs(d, "groups", d.Get("groups").(*schema.Set), groupJobs, results[:len(groupJobs)])
+	}
+	if len(userJobs) > 0 {
+		setAfterJobs(d, "users", d.Get("users").(*schema.Set), userJobs, results[len(groupJobs):])
+	}
+	return jobsError(results, "failed to associate user or groups with application")
 }
 
 func handleAppLogo(ctx context.Context, d *schema.ResourceData, m interface{}, appID string, links interface{}) error {
@@ -436,22 +455,20 @@ func handleAppLogo(ctx context.Context, d *schema.ResourceData, m interface{}, a
 	return err
 }
 
-func handleAppUsers(ctx context.Context, id string, d *schema.ResourceData, client *okta.Client) []func() error {
+func handleAppUsers(ctx context.Context, id string, d *schema.ResourceData, client *okta.Client) ([]setChangeJob, error) {
 	if !d.HasChange("users") {
-		return nil
+		return nil, nil
 	}
 	// temp solution until 'users' field is supported
 	if d.Get("skip_users").(bool) {
-		return nil
+		return nil, nil
 	}
 	existingUsers, err := listApplicationUsers(ctx, client, id)
 	if err != nil {
-		return []func() error{
-			func() error { return err },
-		}
+		return nil, err
 	}
 
-	var asyncActionList []func() error
+	var jobs []setChangeJob
 
 	oldUs, newUs := d.GetChange("users")
 	oldSet := oldUs.(*schema.Set)
@@ -464,31 +481,38 @@ func handleAppUsers(ctx context.Context, id string, d *schema.ResourceData, clie
 		uID := userProfile["id"].(string)
 		username := userProfile["username"].(string)
 		password := userProfile["password"].(string)
+		appUser := okta.AppUser{
+			Id: uID,
+			Credentials: &okta.AppUserCredentials{
+				UserName: username,
+				Password: &okta.AppUserPasswordCredential{
+					Value: password,
+				},
+			},
+		}
 		if shouldUpdateUser(existingUsers, uID, username) {
-			asyncActionList = append(asyncActionList, func() error {
-				_, _, err := client.Application.UpdateApplicationUser(ctx, id, uID, okta.AppUser{
-					Id: uID,
-					Credentials: &okta.AppUserCredentials{
-						UserName: username,
-						Password: &okta.AppUserPasswordCredential{
-							Value: password,
-						},
+			jobs = append(jobs, setChangeJob{
+				job: job{
+					name: fmt.Sprintf("update user (%s) of application (%s)", uID, id),
+					run: func(ctx context.Context) error {
+						_, _, err := client.Application.UpdateApplicationUser(ctx, id, uID, appUser)
+						return err
 					},
-				})
-				return err
+				},
+				value: usersToAdd[i],
+				add:   true,
 			})
 		} else {
-			asyncActionList = append(asyncActionList, func() error {
-				_, _, err := client.Application.AssignUserToApplication(ctx, id, okta.AppUser{
-					Id: uID,
-					Credentials: &okta.AppUserCredentials{
-						UserName: username,
-						Password: &okta.AppUserPasswordCredential{
-							Value: password,
-						},
+			jobs = append(jobs, setChangeJob{
+				job: job{
+					name: fmt.Sprintf("assign user (%s) to application (%s)", uID, id),
+					run: func(ctx context.Context) error {
+						_, _, err := client.Application.AssignUserToApplication(ctx, id, appUser)
+						return err
 					},
-				})
-				return err
+				},
+				value: usersToAdd[i],
+				add:   true,
 			})
 		}
 	}
@@ -496,12 +520,18 @@ func handleAppUsers(ctx context.Context, id string, d *schema.ResourceData, clie
 	for i := range usersToRemove {
 		uID := usersToRemove[i].(map[string]interface{})["id"].(string)
 		if containsAppUser(existingUsers, uID) {
-			asyncActionList = append(asyncActionList, func() error {
-				return suppressErrorOn404(client.Application.DeleteApplicationUser(ctx, id, uID, nil))
+			jobs = append(jobs, setChangeJob{
+				job: job{
+					name: fmt.Sprintf("unassign user (%s) from application (%s)", uID, id),
+					run: func(ctx context.Context) error {
+						return suppressErrorOn404(client.Application.DeleteApplicationUser(ctx, id, uID, nil))
+					},
+				},
+				value: usersToRemove[i],
 			})
 		}
 	}
-	return asyncActionList
+	return jobs, nil
 }
 
 func listApplicationUsers(ctx context.Context, client *okta.Client, id string) ([]*okta.AppUser, error) {
@@ -17,6 +17,7 @@ import (
 	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
 	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 	"github.com/okta/terraform-provider-okta/sdk"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (adt *AddHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -30,30 +31,59 @@ type (
 		T http.RoundTripper
 	}
 
+	// rateLimitOverride is an admin defined rate limit on the endpoints matching
+	// the method and path regular expression
+	rateLimitOverride struct {
+		pathRegex string
+		method    string
+		limit     int
+	}
+
 	// Config contains our provider schema values and Okta clients
 	Config struct {
-		orgName          string
-		domain           string
-		httpProxy        string
-		accessToken      string
-		apiToken         string
-		clientID         string
-		privateKey       string
-		privateKeyId     string
-		scopes           []string
-		retryCount       int
-		parallelism      int
-		backoff          bool
-		minWait          int
-		maxWait          int
-		logLevel         int
-		requestTimeout   int
-		maxAPICapacity   int // experimental
-		oktaClient       *okta.Client
-		supplementClient *sdk.APISupplement
-		client           *http.Client
-		logger           hclog.Logger
-		classicOrg       bool
+		orgName            string
+		domain             string
+		httpProxy          string
+		accessToken        string
+		apiToken           string
+		clientID           string
+		privateKey         string
+		privateKeyId       string
+		privateKeyFile     string
+		keyPassphrase      string // passphrase of an encrypted private key
+		scopes             []string
+		retryCount         int
+		parallelism        int
+		backoff            bool
+		minWait            int
+		maxWait            int
+		logLevel           int
+		requestTimeout     int
+		maxAPICapacity     int                 // experimental
+		rateLimitOverrides []rateLimitOverride // experimental
+		rateLimitFile      string              // experimental, empty unless the rate limits are shared through a file
+		telemetryFile      string
+		readOnly           bool
+		readCache          bool
+		traceExporter      string
+		traceOTLPEndpoint  string
+		traceOTLPHeaders   map[string]string
+		traceFile          string
+		tracer             trace.Tracer
+		oktaClient         *okta.Client
+		supplementClient   *sdk.APISupplement
+		client             *http.Client
+		logger             hclog.Logger
+		classicOrg         bool
+		oieOrg             bool // neither is set when the kind of org is unknown
+		// httpTransport optionally replaces the base transport of the http
+		// client, tests use it to record and replay Okta API traffic
+		httpTransport http.RoundTripper
+		// circuit breaker of the Okta API, opens after the threshold of
+		// consecutive server errors or timeouts, disabled at 0
+		circuitBreakerThreshold       int
+		circuitBreakerClassThresholds map[string]int
+		circuitBreakerCooldown        int
 	}
 )
 
@@ -67,6 +97,13 @@ func (c *Config) loadAndValidate(ctx context.Context) error {
 		Level:      logLevel,
 		TimeFormat: "2006/01/02 03:04:05",
 	})
+	// the api mutex classifies the endpoints for the transports below, and
+	// keeps account of the rate limits when max_api_capacity is in use
+	apiMutex, err := c.newAPIMutex()
+	if err != nil {
+		return err
+	}
+
 	var httpClient *http.Client
 	if c.backoff {
 		retryableClient := retryablehttp.NewClient()
@@ -74,25 +111,73 @@ func (c *Config) loadAndValidate(ctx context.Context) error {
 		retryableClient.RetryWaitMax = time.Second * time.Duration(c.maxWait)
 		retryableClient.RetryMax = c.retryCount
 		retryableClient.Logger = c.logger
+		if c.httpTransport != nil {
+			retryableClient.HTTPClient.Transport = c.httpTransport
+		}
 		retryableClient.HTTPClient.Transport = logging.NewTransport("Okta", retryableClient.HTTPClient.Transport)
+		// the circuit breaker sees every attempt, an open circuit isn't retried
+		retryableClient.HTTPClient.Transport = c.circuitBreakerTransport(retryableClient.HTTPClient.Transport, apiMutex)
+		if c.telemetryFile != "" {
+			// lets the telemetry count the retries of each request
+			retryableClient.HTTPClient.Transport = transport.NewAttemptTransport(retryableClient.HTTPClient.Transport)
+		}
 		retryableClient.ErrorHandler = errHandler
 		retryableClient.CheckRetry = checkRetry
 		httpClient = retryableClient.StandardClient()
 		c.logger.Info(fmt.Sprintf("running with backoff http client, wait min %d, wait max %d, retry max %d", retryableClient.RetryWaitMin, retryableClient.RetryWaitMax, retryableClient.RetryMax))
 	} else {
 		httpClient = cleanhttp.DefaultClient()
+		if c.httpTransport != nil {
+			httpClient.Transport = c.httpTransport
+		}
 		httpClient.Transport = logging.NewTransport("Okta", httpClient.Transport)
+		httpClient.Transport = c.circuitBreakerTransport(httpClient.Transport, apiMutex)
 		c.logger.Info("running with default http client")
 	}
 
 	// adds transport governor to retryable or default client
 	if c.maxAPICapacity > 0 && c.maxAPICapacity < 100 {
 		c.logger.Info(fmt.Sprintf("running with experimental max_api_capacity configuration at %d%%", c.maxAPICapacity))
-		apiMutex, err := apimutex.NewAPIMutex(c.maxAPICapacity)
+		governedTransport := transport.NewGovernedTransport(httpClient.Transport, apiMutex, c.logger)
+		registerShutdownHook(governedTransport.LogStats)
+		httpClient.Transport = governedTransport
+	}
+
+	// adds telemetry on top of the transport stack, so a round trip includes
+	// its pacing and retries
+	if c.telemetryFile != "" {
+		c.logger.Info(fmt.Sprintf("writing API telemetry to %q", c.telemetryFile))
+		telemetryTransport, err := transport.NewTelemetryTransport(httpClient.Transport, c.telemetryFile, apiMutex, c.logger)
 		if err != nil {
 			return err
 		}
-		httpClient.Transport = transport.NewGovernedTransport(httpClient.Transport, apiMutex, c.logger)
+		registerShutdownHook(telemetryTransport.Close)
+		httpClient.Transport = telemetryTransport
+	}
+
+	// refuses the requests that could change the org, before they are paced or
+	// retried
+	if c.readOnly {
+		c.logger.Info("running in read only mode, only requests reading from the org are made")
+		httpClient.Transport = transport.NewReadOnlyTransport(httpClient.Transport)
+	}
+
+	// serves repeated reads from memory, a cache hit isn't paced, retried or
+	// counted in the telemetry as it doesn't make an API request
+	if c.readCache {
+		c.logger.Info("running with the API read cache")
+		cacheTransport := transport.NewCacheTransport(httpClient.Transport, c.logger)
+		registerShutdownHook(cacheTransport.LogStats)
+		httpClient.Transport = cacheTransport
+	}
+
+	// adds a span per request on top of the transport stack, as a child of the
+	// span of the resource operation making it
+	if err := c.configureTracing(ctx); err != nil {
+		return err
+	}
+	if c.tracer != nil {
+		httpClient.Transport = transport.NewTracingTransport(httpClient.Transport, c.tracer, apiMutex)
 	}
 
 	var orgUrl string
@@ -127,10 +212,17 @@ func (c *Config) loadAndValidate(ctx context.Context) error {
 			okta.WithToken(c.apiToken), okta.WithAuthorizationMode("SSWS"),
 		)
 
-	case c.privateKey != "":
+	case c.privateKey != "" || c.privateKeyFile != "":
+		if c.clientID == "" || len(c.scopes) == 0 {
+			return errors.New("client_id and scopes are required to authenticate with a private key")
+		}
+		// the OAuth transport mints the access tokens, and mints new ones as
+		// they expire, it replaces the placeholder token the SDK sends
+		mint := c.accessTokenMinter(orgUrl, &http.Client{Transport: httpClient.Transport})
+		httpClient.Transport = transport.NewOAuthTransport(httpClient.Transport, oauthTokenPath, mint)
 		setters = append(
 			setters,
-			okta.WithPrivateKey(c.privateKey), okta.WithPrivateKeyId(c.privateKeyId), okta.WithScopes(c.scopes), okta.WithClientId(c.clientID), okta.WithAuthorizationMode("PrivateKey"),
+			okta.WithToken(oauthTokenPlaceholder), okta.WithAuthorizationMode("Bearer"),
 		)
 	}
 
@@ -157,6 +249,30 @@ func (c *Config) loadAndValidate(ctx context.Context) error {
 	return nil
 }
 
+// internalAPI returns how to reach the internal API of the admin host of the
+// org with the http client of the config, so the requests go through the
+// http_proxy, the governed transport and the other transports like the Okta
+// SDK ones. The requests are authenticated in the same way too, with a private
+// key the OAuth transport replaces the placeholder token with an access token.
+func (c *Config) internalAPI() sdk.InternalAPI {
+	api := sdk.InternalAPI{
+		URL:    fmt.Sprintf("https://%s-admin.%s", c.orgName, c.domain),
+		Client: c.client,
+	}
+	if c.httpProxy != "" {
+		api.URL = strings.TrimSuffix(c.httpProxy, "/")
+	}
+	switch {
+	case c.accessToken != "":
+		api.Authorization = "Bearer " + c.accessToken
+	case c.apiToken != "":
+		api.Authorization = "SSWS " + c.apiToken
+	default:
+		api.Authorization = "Bearer " + oauthTokenPlaceholder
+	}
+	return api
+}
+
 func errHandler(resp *http.Response, err error, numTries int) (*http.Response, error) {
 	if err != nil {
 		return resp, err
@@ -178,6 +294,46 @@ type contextKey string
 
 const retryOnStatusCodes contextKey = "retryOnStatusCodes"
 
+// newAPIMutex returns the api mutex of the config's max_api_capacity, with the
+// rate limit overrides and the shared backend configured.
+func (c *Config) newAPIMutex() (*apimutex.APIMutex, error) {
+	capacity := 100
+	if c.maxAPICapacity > 0 && c.maxAPICapacity < 100 {
+		capacity = c.maxAPICapacity
+	}
+	apiMutex, err := apimutex.NewAPIMutex(capacity)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range c.rateLimitOverrides {
+		if err := apiMutex.AddOverride(o.method, o.pathRegex, o.limit); err != nil {
+			return nil, err
+		}
+	}
+	if c.rateLimitFile != "" && capacity < 100 {
+		c.logger.Info(fmt.Sprintf("sharing API rate limits with other provider processes through %q", c.rateLimitFile))
+		backend, err := apimutex.NewFileBackend(c.rateLimitFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := apiMutex.SetBackend(backend); err != nil {
+			return nil, err
+		}
+	}
+	return apiMutex, nil
+}
+
+// circuitBreakerTransport returns the base transport behind a circuit breaker,
+// or as is if the circuit breaker isn't configured.
+func (c *Config) circuitBreakerTransport(base http.RoundTripper, apiMutex *apimutex.APIMutex) http.RoundTripper {
+	if c.circuitBreakerThreshold <= 0 && len(c.circuitBreakerClassThresholds) == 0 {
+		return base
+	}
+	c.logger.Info(fmt.Sprintf("running with circuit breaker, threshold %d, class thresholds %v, cool down %ds", c.circuitBreakerThreshold, c.circuitBreakerClassThresholds, c.circuitBreakerCooldown))
+	return transport.NewCircuitBreakerTransport(base, apiMutex, c.circuitBreakerThreshold, c.circuitBreakerClassThresholds,
+		time.Second*time.Duration(c.circuitBreakerCooldown), c.logger)
+}
+
 // Used to make http client retry on provided list of response status codes
 //
 // To enable this check, inject `retryOnStatusCodes` key into the context with list of status codes you want to retry on
@@ -192,6 +348,10 @@ func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, erro
 	if ok && resp != nil && containsInt(retryCodes, resp.StatusCode) {
 		return true, nil
 	}
+	// don't retry when the circuit breaker is open, it fails fast on purpose
+	if errors.Is(err, transport.ErrCircuitOpen) {
+		return false, err
+	}
 	// don't retry on internal server errors
 	if resp != nil && resp.StatusCode == http.StatusInternalServerError {
 		return false, nil
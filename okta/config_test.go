@@ -2,10 +2,19 @@ package okta
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func TestConfigLoadAndValidate(t *testing.T) {
@@ -50,3 +59,87 @@ func TestConfigLoadAndValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigInternalAPI(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalECPrivateKey(key)
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case oauthTokenPath:
+			_, _ = w.Write([]byte(`{"token_type":"Bearer","expires_in":3600,"access_token":"minted-token"}`))
+		case "/api/v1/users/me":
+			_, _ = w.Write([]byte(`{"id":"00u1"}`))
+		case "/api/internal/org/settings/security-notification-settings":
+			authorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"sendEmailForNewDeviceEnabled":true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		accessToken   string
+		apiToken      string
+		privateKey    string
+		authorization string
+	}{
+		{name: "access_token", accessToken: "access-token", authorization: "Bearer access-token"},
+		{name: "api_token", apiToken: "api-token", authorization: "SSWS api-token"},
+		{name: "private_key", privateKey: privateKey, authorization: "Bearer minted-token"},
+	}
+	for _, test := range tests {
+		config := Config{
+			orgName:     "test",
+			domain:      "okta.com",
+			httpProxy:   server.URL,
+			accessToken: test.accessToken,
+			apiToken:    test.apiToken,
+			clientID:    "client-id",
+			privateKey:  test.privateKey,
+			scopes:      []string{"okta.orgs.manage"},
+			logLevel:    int(hclog.Warn),
+		}
+		if err := config.loadAndValidate(context.Background()); err != nil {
+			t.Fatalf("test %q: did not expect error but received error: %+v", test.name, err)
+		}
+		authorization = ""
+		emails, err := config.supplementClient.GetSecurityNotificationEmails(context.Background(), config.internalAPI())
+		if err != nil {
+			t.Errorf("test %q: did not expect error but received error: %+v", test.name, err)
+			continue
+		}
+		if !emails.SendEmailForNewDeviceEnabled {
+			t.Errorf("test %q: expected the settings of the response, got %+v", test.name, emails)
+		}
+		if authorization != test.authorization {
+			t.Errorf("test %q: expected the request to be authorized with %q, got %q", test.name, test.authorization, authorization)
+		}
+	}
+}
+
+func TestCheckRetry(t *testing.T) {
+	tests := []struct {
+		name  string
+		resp  *http.Response
+		err   error
+		retry bool
+	}{
+		{"500 isn't retried", &http.Response{StatusCode: http.StatusInternalServerError}, nil, false},
+		{"503 is retried", &http.Response{StatusCode: http.StatusServiceUnavailable}, nil, true},
+		{"429 is retried", &http.Response{StatusCode: http.StatusTooManyRequests}, nil, true},
+		{"open circuit isn't retried", nil, &url.Error{Op: "Get", URL: "https://test.okta.com/api/v1/groups", Err: fmt.Errorf("%w for the \"groups\" endpoint class", transport.ErrCircuitOpen)}, false},
+	}
+	for _, test := range tests {
+		retry, _ := checkRetry(context.Background(), test.resp, test.err)
+		if retry != test.retry {
+			t.Errorf("test %q: expected retry to be %t, got %t", test.name, test.retry, retry)
+		}
+	}
+}
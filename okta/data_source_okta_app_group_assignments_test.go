@@ -3,16 +3,15 @@ package okta
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaDataSourceAppGroupAssignments_read(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appGroupAssignments)
 	config := mgr.GetFixtures("datasource.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		Steps: []resource.TestStep{
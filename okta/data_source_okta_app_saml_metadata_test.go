@@ -3,17 +3,16 @@ package okta
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaDataSourceAppMetadataSaml_read(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appMetadataSaml)
 	config := mgr.GetFixtures("datasource.tf", ri, t)
 	resourceName := "data.okta_app_metadata_saml.test"
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
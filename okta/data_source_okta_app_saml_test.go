@@ -4,17 +4,16 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaDataSourceAppSaml_read(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSaml)
 	config := mgr.GetFixtures("datasource.tf", ri, t)
 	appCreate := buildTestAppSaml(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -4,17 +4,16 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaDataSourceApp_read(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(app)
 	config := mgr.GetFixtures("datasource.tf", ri, t)
 	appCreate := buildTestApp(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -53,10 +52,10 @@ resource "okta_app_oauth" "test" {
 }
 
 func TestAccOktaDataSourceAppLabelTest_read(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := testLabelConfig(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
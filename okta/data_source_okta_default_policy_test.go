@@ -5,16 +5,15 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
 func TestAccOktaDataSourceDefaultPolicy_readPasswordPolicy(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := testAccDataSourceDefaultPolicy(ri, sdk.PasswordPolicyType)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -31,10 +30,10 @@ func TestAccOktaDataSourceDefaultPolicy_readPasswordPolicy(t *testing.T) {
 }
 
 func TestAccOktaDataSourceDefaultPolicy_readIdpPolicy(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := testAccDataSourceDefaultPolicy(ri, sdk.IdpDiscoveryType)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func dataSourceGroup() *schema.Resource {
@@ -62,6 +63,8 @@ func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interfac
 		if err == nil {
 			logger(m).Info("delaying group read by ", delay, " seconds")
 			time.Sleep(time.Duration(delay) * time.Second)
+			// the read is delayed to see the latest changes, not the cached responses
+			ctx = transport.SkipCache(ctx)
 		} else {
 			logger(m).Warn("group read delay value ", n, " is not an integer")
 		}
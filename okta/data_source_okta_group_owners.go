@@ -0,0 +1,77 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroupOwners() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGroupOwnersRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of a Okta group.",
+			},
+			"owners": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"origin_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"origin_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resolved": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGroupOwnersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	owners, _, err := listGroupOwners(ctx, getSupplementFromMetadata(m), groupId)
+	if err != nil {
+		return diag.Errorf("failed to list owners of group %q: %v", groupId, err)
+	}
+	d.SetId(groupId)
+	arr := make([]map[string]interface{}, len(owners))
+	for i, owner := range owners {
+		arr[i] = map[string]interface{}{
+			"id":           owner.ID,
+			"type":         owner.Type,
+			"display_name": owner.DisplayName,
+			"origin_id":    owner.OriginID,
+			"origin_type":  owner.OriginType,
+		}
+		if owner.Resolved != nil {
+			arr[i]["resolved"] = *owner.Resolved
+		}
+	}
+	err = d.Set("owners", arr)
+	return diag.FromErr(err)
+}
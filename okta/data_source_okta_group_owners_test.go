@@ -0,0 +1,58 @@
+package okta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestAccOktaDataSourceGroupOwners_read(t *testing.T) {
+	ri := vcrRandInt(t)
+	mgr := newFixtureManager(groupOwners)
+	config := mgr.GetFixtures("datasource.tf", ri, t)
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.okta_group_owners.test", "owners.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.okta_group_owners.test", "owners.*", map[string]string{"type": sdk.GroupOwnerTypeUser}),
+				),
+			},
+		},
+	})
+}
+
+func TestGroupOwnersDataSourceRead(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	groupId := srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": "testAcc_owned"}})
+	for i := 0; i < 3; i++ {
+		srv.Put("/groups/"+groupId+"/owners", map[string]interface{}{
+			"id":          srv.Put("/users", map[string]interface{}{}),
+			"type":        sdk.GroupOwnerTypeUser,
+			"displayName": "Test Acc",
+			"resolved":    true,
+		})
+	}
+
+	r := dataSourceGroupOwners()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"group_id": groupId})
+	if diags := r.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("failed to read group owners: %v", diags)
+	}
+	if d.Get("owners.#") != 3 {
+		t.Fatalf("expected 3 owners, got %v", d.Get("owners.#"))
+	}
+	if d.Get("owners.0.display_name") != "Test Acc" || d.Get("owners.0.resolved") != true {
+		t.Fatalf("unexpected owner: %v", d.Get("owners.0"))
+	}
+}
@@ -3,18 +3,17 @@ package okta
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaDataSourceIdpSaml_read(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(idpSaml)
 	config := mgr.GetFixtures("datasource.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("datasource_id.tf", ri, t)
 	idpSaml := mgr.GetFixtures("basic.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
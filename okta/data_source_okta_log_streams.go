@@ -0,0 +1,157 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+type logStreamsDataSourceType struct{}
+
+type logStreamsDataSource struct {
+	provider *frameworkProvider
+}
+
+type logStreamsModel struct {
+	ID         types.String          `tfsdk:"id"`
+	Type       types.String          `tfsdk:"type"`
+	Status     types.String          `tfsdk:"status"`
+	LogStreams []logStreamsItemModel `tfsdk:"log_streams"`
+}
+
+type logStreamsItemModel struct {
+	ID       types.String                `tfsdk:"id"`
+	Name     types.String                `tfsdk:"name"`
+	Type     types.String                `tfsdk:"type"`
+	Status   types.String                `tfsdk:"status"`
+	Settings logStreamsItemSettingsModel `tfsdk:"settings"`
+}
+
+type logStreamsItemSettingsModel struct {
+	AccountID       types.String `tfsdk:"account_id"`
+	EventSourceName types.String `tfsdk:"event_source_name"`
+	Region          types.String `tfsdk:"region"`
+	Edition         types.String `tfsdk:"edition"`
+	Host            types.String `tfsdk:"host"`
+}
+
+func (logStreamsDataSourceType) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Get the log streams of the org, of a type or status.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "The filter of the log streams",
+			},
+			"type": {
+				Type:        types.StringType,
+				Optional:    true,
+				Validators:  []tfsdk.AttributeValidator{stringOneOf(sdk.LogStreamTypeAWSEventBridge, sdk.LogStreamTypeSplunkCloud)},
+				Description: "Type of the log streams: aws_eventbridge or splunk_cloud_logstreaming",
+			},
+			"status": {
+				Type:        types.StringType,
+				Optional:    true,
+				Validators:  []tfsdk.AttributeValidator{stringOneOf(statusActive, statusInactive)},
+				Description: "Status of the log streams: ACTIVE or INACTIVE",
+			},
+			"log_streams": {
+				Type: types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"id":     types.StringType,
+					"name":   types.StringType,
+					"type":   types.StringType,
+					"status": types.StringType,
+					"settings": types.ObjectType{AttrTypes: map[string]attr.Type{
+						"account_id":        types.StringType,
+						"event_source_name": types.StringType,
+						"region":            types.StringType,
+						"edition":           types.StringType,
+						"host":              types.StringType,
+					}},
+				}}},
+				Computed:    true,
+				Description: "The log streams, with their id, name, type, status and settings",
+			},
+		},
+	}, nil
+}
+
+func (logStreamsDataSourceType) NewDataSource(_ context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	return &logStreamsDataSource{provider: p.(*frameworkProvider)}, nil
+}
+
+func (d *logStreamsDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var model logStreamsModel
+	ctx, span := startFrameworkSpan(ctx, d.provider.config, "data."+logStreams, "read")
+	defer func() { endFrameworkSpan(span, model.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var filters []string
+	if model.Type.Value != "" {
+		filters = append(filters, fmt.Sprintf(`type eq "%s"`, model.Type.Value))
+	}
+	if model.Status.Value != "" {
+		filters = append(filters, fmt.Sprintf(`status eq "%s"`, model.Status.Value))
+	}
+	qp := &query.Params{Limit: defaultPaginationLimit, Filter: strings.Join(filters, " and ")}
+	client := d.provider.config.supplementClient
+	streams, apiResp, err := client.ListLogStreams(ctx, qp)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list log streams", err.Error())
+		return
+	}
+	for {
+		for _, stream := range streams {
+			item := logStreamsItemModel{
+				ID:     types.String{Value: stream.ID},
+				Name:   types.String{Value: stream.Name},
+				Type:   types.String{Value: stream.Type},
+				Status: types.String{Value: stream.Status},
+				Settings: logStreamsItemSettingsModel{
+					AccountID:       types.String{Null: true},
+					EventSourceName: types.String{Null: true},
+					Region:          types.String{Null: true},
+					Edition:         types.String{Null: true},
+					Host:            types.String{Null: true},
+				},
+			}
+			if stream.Settings != nil {
+				item.Settings = logStreamsItemSettingsModel{
+					AccountID:       stringValue(stream.Settings.AccountID),
+					EventSourceName: stringValue(stream.Settings.EventSourceName),
+					Region:          stringValue(stream.Settings.Region),
+					Edition:         stringValue(stream.Settings.Edition),
+					Host:            stringValue(stream.Settings.Host),
+				}
+			}
+			model.LogStreams = append(model.LogStreams, item)
+		}
+		if !apiResp.HasNextPage() {
+			break
+		}
+		streams = nil
+		apiResp, err = apiResp.Next(ctx, &streams)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list log streams", err.Error())
+			return
+		}
+	}
+	if model.LogStreams == nil {
+		model.LogStreams = []logStreamsItemModel{}
+	}
+	model.ID = types.String{Value: qp.Filter}
+	if qp.Filter == "" {
+		model.ID = types.String{Value: logStreams}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
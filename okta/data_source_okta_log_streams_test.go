@@ -0,0 +1,78 @@
+package okta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestAccOktaDataSourceLogStreams_read(t *testing.T) {
+	ri := vcrRandInt(t)
+	mgr := newFixtureManager(logStreams)
+	config := mgr.GetFixtures("datasource.tf", ri, t)
+	oktaResourceTest(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("data.okta_log_streams.test", "log_streams.#"),
+						resource.TestCheckResourceAttr("data.okta_log_streams.test", "log_streams.0.type", sdk.LogStreamTypeAWSEventBridge),
+					),
+				},
+			},
+		})
+}
+
+func TestLogStreamsDataSourceFilter(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	aws := srv.Put("/logStreams", map[string]interface{}{
+		"name": "aws", "type": sdk.LogStreamTypeAWSEventBridge, "status": statusActive,
+		"settings": map[string]interface{}{"accountId": "123456789012", "eventSourceName": "aws", "region": "us-east-1"},
+	})
+	srv.Put("/logStreams", map[string]interface{}{
+		"name": "splunk", "type": sdk.LogStreamTypeSplunkCloud, "status": statusActive,
+		"settings": map[string]interface{}{"edition": "aws", "host": "acme.splunkcloud.com"},
+	})
+
+	ctx := context.Background()
+	s, _ := logStreamsDataSourceType{}.GetSchema(ctx)
+	d := &logStreamsDataSource{provider: &frameworkProvider{config: config}}
+	model := logStreamsModel{
+		ID:     types.String{Null: true},
+		Type:   types.String{Value: sdk.LogStreamTypeAWSEventBridge},
+		Status: types.String{Null: true},
+	}
+	req := tfsdk.ReadDataSourceRequest{Config: tfsdk.Config{Schema: s}}
+	state := tfsdk.State{Schema: s}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	req.Config.Raw = state.Raw
+	resp := tfsdk.ReadDataSourceResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.TerraformType(ctx), nil)}}
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("failed to read log streams: %v", resp.Diagnostics)
+	}
+	resp.State.Get(ctx, &model)
+	if len(model.LogStreams) != 1 || model.LogStreams[0].ID.Value != aws {
+		t.Fatalf("expected only the aws_eventbridge log stream, got %+v", model.LogStreams)
+	}
+	if settings := model.LogStreams[0].Settings; settings.Region.Value != "us-east-1" || !settings.Host.Null {
+		t.Fatalf("unexpected settings %+v", settings)
+	}
+	if model.ID.Value != `type eq "aws_eventbridge"` {
+		t.Fatalf("expected the filter to be the ID, got %s", model.ID.Value)
+	}
+}
@@ -0,0 +1,228 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+// systemLogPageLimit is the largest page of System Log events the API returns.
+const systemLogPageLimit = 1000
+
+func dataSourceSystemLog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSystemLogRead,
+		Schema: map[string]*schema.Schema{
+			"since": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringIsTimestamp,
+				Description:      "Only the events published at or after this RFC 3339 timestamp are returned. By default, the events of the last 7 days are returned.",
+			},
+			"until": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: stringIsTimestamp,
+				Description:      "Only the events published before this RFC 3339 timestamp are returned.",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: `Filter expression of the events, e.g. eventType eq "policy.lifecycle.update"`,
+			},
+			"q": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Searches the events for matching keywords, separated by spaces",
+			},
+			"sort_order": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "ASCENDING",
+				ValidateDiagFunc: elemInSlice([]string{"ASCENDING", "DESCENDING"}),
+				Description:      "Order of the events by their published timestamp: ASCENDING or DESCENDING",
+			},
+			"limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: intAtLeast(1),
+				Description:      "Maximum number of events returned",
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"published": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     systemLogEntityResource,
+						},
+						"target": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     systemLogEntityResource,
+						},
+						"outcome": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"result": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"reason": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"raw": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The event as JSON, as it is returned by the API",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// systemLogEntityResource is the actor or a target of an event.
+var systemLogEntityResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"alternate_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"display_name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	},
+}
+
+func dataSourceSystemLogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	limit := d.Get("limit").(int)
+	qp := &query.Params{
+		Since:     d.Get("since").(string),
+		Until:     d.Get("until").(string),
+		Filter:    d.Get("filter").(string),
+		Q:         d.Get("q").(string),
+		SortOrder: d.Get("sort_order").(string),
+		Limit:     int64(limit),
+	}
+	if limit > systemLogPageLimit {
+		qp.Limit = systemLogPageLimit
+	}
+	events, err := collectLogEvents(ctx, getSupplementFromMetadata(m), qp, limit)
+	if err != nil {
+		return diag.Errorf("failed to list system log events: %v", err)
+	}
+	d.SetId(fmt.Sprintf("%d", crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s&total=%d", qp.String(), limit)))))
+	arr := make([]map[string]interface{}, len(events))
+	for i := range events {
+		var event okta.LogEvent
+		if err := json.Unmarshal(events[i], &event); err != nil {
+			return diag.Errorf("failed to decode system log event: %v", err)
+		}
+		arr[i] = map[string]interface{}{
+			"uuid":            event.Uuid,
+			"event_type":      event.EventType,
+			"display_message": event.DisplayMessage,
+			"severity":        event.Severity,
+			"raw":             string(events[i]),
+		}
+		if event.Published != nil {
+			arr[i]["published"] = event.Published.UTC().Format(time.RFC3339Nano)
+		}
+		if event.Actor != nil {
+			arr[i]["actor"] = []interface{}{flattenLogEntity(event.Actor.Id, event.Actor.Type, event.Actor.AlternateId, event.Actor.DisplayName)}
+		}
+		targets := make([]interface{}, len(event.Target))
+		for j, target := range event.Target {
+			targets[j] = flattenLogEntity(target.Id, target.Type, target.AlternateId, target.DisplayName)
+		}
+		arr[i]["target"] = targets
+		if event.Outcome != nil {
+			arr[i]["outcome"] = []interface{}{map[string]interface{}{
+				"result": event.Outcome.Result,
+				"reason": event.Outcome.Reason,
+			}}
+		}
+	}
+	err = d.Set("events", arr)
+	return diag.FromErr(err)
+}
+
+// collectLogEvents gets up to limit events, following the Link headers of the
+// pages. Without until, the API is polled: every page has a next link, even the
+// last one, so an empty page means there are no more events.
+func collectLogEvents(ctx context.Context, client *sdk.APISupplement, qp *query.Params, limit int) ([]json.RawMessage, error) {
+	events, resp, err := client.ListLogEvents(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+	page := events
+	for len(events) < limit && len(page) > 0 && resp.HasNextPage() {
+		page = nil
+		resp, err = resp.Next(ctx, &page)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+	}
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func flattenLogEntity(id, typ, alternateID, displayName string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           id,
+		"type":         typ,
+		"alternate_id": alternateID,
+		"display_name": displayName,
+	}
+}
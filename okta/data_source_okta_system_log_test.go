@@ -0,0 +1,120 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccOktaDataSourceSystemLog_read(t *testing.T) {
+	ri := vcrRandInt(t)
+	mgr := newFixtureManager(systemLog)
+	config := mgr.GetFixtures("datasource.tf", ri, t)
+	oktaResourceTest(
+		t, resource.TestCase{
+			PreCheck:          func() { testAccPreCheck(t) },
+			ProviderFactories: testAccProvidersFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.okta_system_log.test", "events.#", "1"),
+						resource.TestCheckResourceAttr("data.okta_system_log.test", "events.0.event_type", "group.lifecycle.create"),
+						resource.TestCheckResourceAttr("data.okta_system_log.test", "events.0.outcome.0.result", "SUCCESS"),
+						resource.TestCheckResourceAttrPair("data.okta_system_log.test", "events.0.target.0.id", "okta_group.test", "id"),
+						resource.TestCheckResourceAttrSet("data.okta_system_log.test", "events.0.actor.0.id"),
+						resource.TestCheckResourceAttrSet("data.okta_system_log.test", "events.0.raw"),
+					),
+				},
+			},
+		})
+}
+
+// TestSystemLogPolling checks the events are paginated until the limit, or
+// until the first empty page as the fake server, like the API, has a next link
+// on every page when until isn't set.
+func TestSystemLogPolling(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	for _, eventType := range []string{"user.session.start", "policy.lifecycle.update", "user.session.start", "policy.lifecycle.update", "user.session.start"} {
+		srv.Put("/logs", map[string]interface{}{
+			"eventType":      eventType,
+			"displayMessage": eventType,
+			"severity":       "INFO",
+			"published":      "2022-08-01T00:00:00Z",
+			"actor":          map[string]interface{}{"id": "00u1fakeadmin", "type": "User", "alternateId": "admin@example.com", "displayName": "Admin"},
+			"target":         []interface{}{map[string]interface{}{"id": "00p1", "type": "PolicyEntity", "displayName": "Policy"}},
+			"outcome":        map[string]interface{}{"result": "SUCCESS"},
+			"debugContext":   map[string]interface{}{"debugData": map[string]interface{}{"requestUri": "/api/v1/policies/00p1"}},
+		})
+	}
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	logRequests := func() int {
+		var n int
+		for _, r := range srv.Requests() {
+			if r == "GET /api/v1/logs" {
+				n++
+			}
+		}
+		return n
+	}
+
+	r := dataSourceSystemLog()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"filter": `eventType eq "policy.lifecycle.update"`,
+	})
+	if diags := r.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("failed to read the system log: %v", diags)
+	}
+	if n := d.Get("events.#").(int); n != 2 {
+		t.Fatalf("expected 2 events, got %d", n)
+	}
+	if n := logRequests(); n != 2 {
+		t.Fatalf("expected the polling to stop at the empty page, got %d requests", n)
+	}
+	if v := d.Get("events.0.actor.0.alternate_id"); v != "admin@example.com" {
+		t.Errorf("expected the actor to be set, got %v", v)
+	}
+	if v := d.Get("events.0.target.0.type"); v != "PolicyEntity" {
+		t.Errorf("expected the target to be set, got %v", v)
+	}
+	if v := d.Get("events.0.outcome.0.result"); v != "SUCCESS" {
+		t.Errorf("expected the outcome to be set, got %v", v)
+	}
+	if v := d.Get("events.0.published"); v != "2022-08-01T00:00:00Z" {
+		t.Errorf("expected the published timestamp to be set, got %v", v)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("events.0.raw").(string)), &raw); err != nil {
+		t.Fatalf("expected the raw event to be JSON: %v", err)
+	}
+	if _, ok := raw["debugContext"]; !ok {
+		t.Errorf("expected the raw event to have all its attributes, got %v", raw)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"until": "2022-08-02T00:00:00Z",
+		"limit": 3,
+	})
+	if diags := r.ReadContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("failed to read the system log: %v", diags)
+	}
+	if n := d.Get("events.#").(int); n != 3 {
+		t.Fatalf("expected the events to be limited to 3, got %d", n)
+	}
+	if n := logRequests(); n != 3 {
+		t.Fatalf("expected a single page of 3 events, got %d more requests", n-2)
+	}
+
+	diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"since": "yesterday"}))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "RFC 3339") {
+		t.Errorf("expected since to be validated, got %v", diags)
+	}
+}
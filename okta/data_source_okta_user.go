@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 var userSearchSchemaDescription = "Filter to find " +
@@ -94,6 +95,8 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 		if err == nil {
 			logger(m).Info("delaying user read by ", delay, " seconds")
 			time.Sleep(time.Duration(delay) * time.Second)
+			// the read is delayed to see the latest changes, not the cached responses
+			ctx = transport.SkipCache(ctx)
 		} else {
 			logger(m).Warn("user read delay value ", n, " is not an integer")
 		}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func dataSourceUsers() *schema.Resource {
@@ -79,6 +80,8 @@ func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interfac
 		if err == nil {
 			logger(m).Info("delaying users read by ", delay, " seconds")
 			time.Sleep(time.Duration(delay) * time.Second)
+			// the read is delayed to see the latest changes, not the cached responses
+			ctx = transport.SkipCache(ctx)
 		} else {
 			logger(m).Warn("users read delay value ", n, " is not an integer")
 		}
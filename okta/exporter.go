@@ -0,0 +1,550 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/sdk"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Dir is the directory the .tf files are written to
+	Dir string
+	// Types are the resource types to export, all the supported ones when
+	// empty, see ExportableResourceTypes
+	Types []string
+}
+
+// exportedObject is an object of the org to export, importID is the ID to
+// import it with.
+type exportedObject struct {
+	resourceType string
+	importID     string
+	name         string
+	// referable objects have an Okta object ID as their ID, the attributes of
+	// the other objects having it are written as references to them
+	referable bool
+
+	label string
+	data  *schema.ResourceData
+}
+
+// orgLister lists the objects of the org of one or more resource types.
+type orgLister struct {
+	resourceTypes []string
+	list          func(ctx context.Context, client *okta.Client, wanted map[string]bool) ([]*exportedObject, error)
+}
+
+// orgListers are in the order of the objects referenced by the others, so
+// the referenced objects get their labels first.
+var orgListers = []orgLister{
+	{resourceTypes: []string{group}, list: listOrgGroups},
+	{resourceTypes: []string{user}, list: listOrgUsers},
+	{
+		resourceTypes: []string{
+			appAutoLogin, appBasicAuth, appBookmark, appOAuth, appSaml, appSecurePasswordStore,
+			appSharedCredentials, appSwa, appThreeField, appGroupAssignments,
+		},
+		list: listOrgApps,
+	},
+	{resourceTypes: []string{policyPassword, policySignOn, policyMfa}, list: listOrgPolicies},
+	{resourceTypes: []string{authServer, authServerPolicy}, list: listOrgAuthServers},
+}
+
+// appResourceTypes are the resource types of the apps by sign on mode, the
+// browser plugin apps are told apart by name.
+var appResourceTypes = map[string]string{
+	"AUTO_LOGIN":            appAutoLogin,
+	"BASIC_AUTH":            appBasicAuth,
+	"BOOKMARK":              appBookmark,
+	"OPENID_CONNECT":        appOAuth,
+	"SAML_1_1":              appSaml,
+	"SAML_2_0":              appSaml,
+	"SECURE_PASSWORD_STORE": appSecurePasswordStore,
+	"template_swa":          appSwa,
+	"template_swa3field":    appThreeField,
+	"BROWSER_PLUGIN":        appSharedCredentials,
+}
+
+var policyResourceTypes = map[string]string{
+	sdk.PasswordPolicyType: policyPassword,
+	sdk.SignOnPolicyType:   policySignOn,
+	sdk.MfaPolicyType:      policyMfa,
+}
+
+// ExportableResourceTypes returns the resource types Export supports.
+func ExportableResourceTypes() []string {
+	var types []string
+	for _, l := range orgListers {
+		types = append(types, l.resourceTypes...)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// UnexportableResourceTypes returns the resource types of the provider Export
+// doesn't support, the objects of these types are skipped. Deprecated resource
+// types aren't included.
+func UnexportableResourceTypes() []string {
+	exportable := ExportableResourceTypes()
+	var types []string
+	for name, r := range Provider().ResourcesMap {
+		if r.DeprecationMessage == "" && !contains(exportable, name) {
+			types = append(types, name)
+		}
+	}
+	frameworkResources, _ := (&frameworkProvider{}).GetResources(context.Background())
+	for name := range frameworkResources {
+		if !contains(exportable, name) {
+			types = append(types, name)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Export walks the org the provider's environment variables point at, e.g.
+// OKTA_ORG_NAME and OKTA_API_TOKEN, and writes a .tf file per resource type
+// with the configuration of its objects, and an imports.tf file with an import
+// block per object. The attributes having the ID of another exported object
+// are written as references to it.
+func Export(ctx context.Context, opts ExportOptions) error {
+	wanted := make(map[string]bool)
+	for _, t := range opts.Types {
+		if !contains(ExportableResourceTypes(), t) {
+			return fmt.Errorf("resource type %q can't be exported, the supported types are %s", t, strings.Join(ExportableResourceTypes(), ", "))
+		}
+		wanted[t] = true
+	}
+	if len(wanted) == 0 {
+		for _, t := range ExportableResourceTypes() {
+			wanted[t] = true
+		}
+	}
+
+	p := Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return fmt.Errorf("failed to configure the provider: %s", diags[0].Summary)
+	}
+	m := p.Meta()
+	client := getOktaClientFromMetadata(m)
+
+	var objects []*exportedObject
+	for _, l := range orgListers {
+		if !containsOne(l.resourceTypes, mapKeys(wanted)...) {
+			continue
+		}
+		listed, err := l.list(ctx, client, wanted)
+		if err != nil {
+			return err
+		}
+		for _, o := range listed {
+			if wanted[o.resourceType] {
+				objects = append(objects, o)
+			}
+		}
+	}
+
+	labels := make(map[string]bool)
+	refs := make(map[string]hcl.Traversal)
+	var exported []*exportedObject
+	for _, o := range objects {
+		d, err := readExportedObject(ctx, p.ResourcesMap[o.resourceType], o.importID, m)
+		if err != nil {
+			return fmt.Errorf("failed to read %s %q: %v", o.resourceType, o.importID, err)
+		}
+		if d == nil {
+			continue
+		}
+		o.data = d
+		o.label = exportLabel(o.resourceType, o.name, labels)
+		if _, ok := refs[d.Id()]; o.referable && !ok {
+			refs[d.Id()] = hcl.Traversal{
+				hcl.TraverseRoot{Name: o.resourceType},
+				hcl.TraverseAttr{Name: o.label},
+				hcl.TraverseAttr{Name: "id"},
+			}
+		}
+		exported = append(exported, o)
+	}
+	return writeExport(opts.Dir, p, exported, refs)
+}
+
+// readExportedObject imports and reads the object as terraform import does,
+// it returns nil when the object is gone.
+func readExportedObject(ctx context.Context, r *schema.Resource, importID string, m interface{}) (*schema.ResourceData, error) {
+	d := r.Data(&terraform.InstanceState{ID: importID})
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, m)
+		if err != nil {
+			return nil, err
+		}
+		if len(imported) == 0 {
+			return nil, nil
+		}
+		d = imported[0]
+	}
+	if diags := r.ReadContext(ctx, d, m); diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, nil
+	}
+	return d, nil
+}
+
+func writeExport(dir string, p *schema.Provider, objects []*exportedObject, refs map[string]hcl.Traversal) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	files := make(map[string]*hclwrite.File)
+	imports := hclwrite.NewEmptyFile()
+	for _, o := range objects {
+		f, ok := files[o.resourceType]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[o.resourceType] = f
+		} else {
+			f.Body().AppendNewline()
+		}
+		var self string
+		if o.referable {
+			self = o.data.Id()
+		}
+		block := f.Body().AppendNewBlock("resource", []string{o.resourceType, o.label})
+		writeExportedAttributes(block.Body(), p.ResourcesMap[o.resourceType].Schema, func(k string) interface{} {
+			return o.data.Get(k)
+		}, self, refs)
+
+		if len(imports.Body().Blocks()) > 0 {
+			imports.Body().AppendNewline()
+		}
+		importBlock := imports.Body().AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: o.resourceType},
+			hcl.TraverseAttr{Name: o.label},
+		})
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(o.importID))
+	}
+	for resourceType, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, resourceType+".tf"), f.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(dir, "imports.tf"), imports.Bytes(), 0o644)
+}
+
+// writeExportedAttributes writes the arguments of the schema that are set to
+// something other than their default, self is the ID of the object written so
+// it isn't made a reference to itself.
+func writeExportedAttributes(body *hclwrite.Body, s map[string]*schema.Schema, get func(string) interface{}, self string, refs map[string]hcl.Traversal) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var blocks []string
+	for _, k := range keys {
+		v := get(k)
+		if !isExportedArgument(s[k], v) {
+			continue
+		}
+		if _, ok := s[k].Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+			continue
+		}
+		body.SetAttributeRaw(k, exportTokens(v, self, refs))
+	}
+	for _, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+		for _, item := range exportList(get(k)) {
+			values, _ := item.(map[string]interface{})
+			block := body.AppendNewBlock(k, nil)
+			writeExportedAttributes(block.Body(), elem.Schema, func(k string) interface{} {
+				return values[k]
+			}, self, refs)
+		}
+	}
+}
+
+func isExportedArgument(s *schema.Schema, v interface{}) bool {
+	if (!s.Required && !s.Optional) || s.Deprecated != "" || s.Sensitive || v == nil {
+		return false
+	}
+	if s.Default != nil {
+		return !reflect.DeepEqual(s.Default, v)
+	}
+	if s.Required {
+		return true
+	}
+	switch v := v.(type) {
+	case string:
+		// empty JSON of the attributes holding JSON
+		return v != "" && v != "{}" && v != "[]" && v != "null"
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	case bool:
+		return v
+	case map[string]interface{}:
+		return len(v) > 0
+	default:
+		return len(exportList(v)) > 0
+	}
+}
+
+func exportList(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+func exportTokens(v interface{}, self string, refs map[string]hcl.Traversal) hclwrite.Tokens {
+	switch v := v.(type) {
+	case string:
+		if ref, ok := refs[v]; ok && v != self {
+			return hclwrite.TokensForTraversal(ref)
+		}
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+		for _, k := range keys {
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+				Value: exportTokens(v[k], self, refs),
+			})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	list := exportList(v)
+	elems := make([]hclwrite.Tokens, 0, len(list))
+	for _, e := range list {
+		elems = append(elems, exportTokens(e, self, refs))
+	}
+	return hclwrite.TokensForTuple(elems)
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportLabel returns a resource label made of the object's name that isn't
+// taken yet for the resource type.
+func exportLabel(resourceType, name string, taken map[string]bool) string {
+	label := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+	unique := label
+	for i := 2; taken[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	taken[resourceType+"."+unique] = true
+	return unique
+}
+
+func mapKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func listOrgGroups(ctx context.Context, client *okta.Client, _ map[string]bool) ([]*exportedObject, error) {
+	// the built in and app groups aren't managed by Terraform
+	groups, err := listGroups(ctx, client, &query.Params{Filter: `type eq "OKTA_GROUP"`, Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %v", err)
+	}
+	objects := make([]*exportedObject, 0, len(groups))
+	for _, g := range groups {
+		objects = append(objects, &exportedObject{
+			resourceType: group,
+			importID:     g.Id + "/skip_users",
+			name:         g.Profile.Name,
+			referable:    true,
+		})
+	}
+	return objects, nil
+}
+
+func listOrgUsers(ctx context.Context, client *okta.Client, _ map[string]bool) ([]*exportedObject, error) {
+	users, resp, err := client.User.ListUsers(ctx, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %v", err)
+	}
+	for resp.HasNextPage() {
+		var nextUsers []*okta.User
+		resp, err = resp.Next(ctx, &nextUsers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %v", err)
+		}
+		users = append(users, nextUsers...)
+	}
+	objects := make([]*exportedObject, 0, len(users))
+	for _, u := range users {
+		name := u.Id
+		if u.Profile != nil {
+			if login, ok := (*u.Profile)["login"].(string); ok {
+				name = login
+			}
+		}
+		objects = append(objects, &exportedObject{
+			resourceType: user,
+			importID:     u.Id,
+			name:         name,
+			referable:    true,
+		})
+	}
+	return objects, nil
+}
+
+func listOrgApps(ctx context.Context, client *okta.Client, wanted map[string]bool) ([]*exportedObject, error) {
+	apps, err := listApps(ctx, client, &appFilters{}, defaultPaginationLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %v", err)
+	}
+	var objects []*exportedObject
+	for _, app := range apps {
+		resourceType, ok := appResourceTypes[app.Name]
+		if !ok {
+			resourceType, ok = appResourceTypes[app.SignOnMode]
+		}
+		if !ok {
+			continue
+		}
+		// the assignments are exported as okta_app_group_assignments
+		objects = append(objects, &exportedObject{
+			resourceType: resourceType,
+			importID:     app.Id + "/skip_users/skip_groups",
+			name:         app.Label,
+			referable:    true,
+		})
+		if !wanted[appGroupAssignments] {
+			continue
+		}
+		assignments, _, err := listApplicationGroupAssignments(ctx, client, app.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group assignments of application %q: %v", app.Id, err)
+		}
+		if len(assignments) > 0 {
+			objects = append(objects, &exportedObject{
+				resourceType: appGroupAssignments,
+				importID:     app.Id,
+				name:         app.Label,
+			})
+		}
+	}
+	return objects, nil
+}
+
+func listOrgPolicies(ctx context.Context, client *okta.Client, wanted map[string]bool) ([]*exportedObject, error) {
+	var objects []*exportedObject
+	for policyType, resourceType := range policyResourceTypes {
+		if !wanted[resourceType] {
+			continue
+		}
+		policies, resp, err := client.Policy.ListPolicies(ctx, &query.Params{Type: policyType})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policies: %v", err)
+		}
+		for {
+			for _, _policy := range policies {
+				policy := _policy.(*okta.Policy)
+				// the default policies can't be created, only imported as
+				// their okta_*_default resources
+				if policy.System != nil && *policy.System {
+					continue
+				}
+				objects = append(objects, &exportedObject{
+					resourceType: resourceType,
+					importID:     policy.Id,
+					name:         policy.Name,
+					referable:    true,
+				})
+			}
+			if !resp.HasNextPage() {
+				break
+			}
+			resp, err = resp.Next(ctx, &policies)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list policies: %v", err)
+			}
+		}
+	}
+	// the policy types are walked in the order of the map
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objects[i].resourceType < objects[j].resourceType
+	})
+	return objects, nil
+}
+
+func listOrgAuthServers(ctx context.Context, client *okta.Client, wanted map[string]bool) ([]*exportedObject, error) {
+	servers, resp, err := client.AuthorizationServer.ListAuthorizationServers(ctx, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list authorization servers: %v", err)
+	}
+	for resp.HasNextPage() {
+		var nextServers []*okta.AuthorizationServer
+		resp, err = resp.Next(ctx, &nextServers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list authorization servers: %v", err)
+		}
+		servers = append(servers, nextServers...)
+	}
+	var objects []*exportedObject
+	for _, server := range servers {
+		objects = append(objects, &exportedObject{
+			resourceType: authServer,
+			importID:     server.Id,
+			name:         server.Name,
+			referable:    true,
+		})
+		if !wanted[authServerPolicy] {
+			continue
+		}
+		policies, _, err := client.AuthorizationServer.ListAuthorizationServerPolicies(ctx, server.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policies of authorization server %q: %v", server.Id, err)
+		}
+		for _, policy := range policies {
+			objects = append(objects, &exportedObject{
+				resourceType: authServerPolicy,
+				importID:     server.Id + "/" + policy.Id,
+				name:         server.Name + "_" + policy.Name,
+				referable:    true,
+			})
+		}
+	}
+	return objects, nil
+}
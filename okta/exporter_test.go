@@ -0,0 +1,118 @@
+package okta
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportLabel(t *testing.T) {
+	taken := make(map[string]bool)
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Sales Team", "sales_team"},
+		{"sales-team", "sales_team_2"},
+		{"2FA users", "_2fa_users"},
+		{"", "_"},
+	}
+	for _, test := range tests {
+		if actual := exportLabel(group, test.name, taken); actual != test.expected {
+			t.Errorf("expected label of %q to be %q, got %q", test.name, test.expected, actual)
+		}
+	}
+	if actual := exportLabel(user, "Sales Team", taken); actual != "sales_team" {
+		t.Errorf("expected labels to be unique per resource type, got %q", actual)
+	}
+}
+
+func TestExport(t *testing.T) {
+	s := newFakeOktaServer(t)
+	groupID := s.Put("/groups", map[string]interface{}{
+		"profile": map[string]interface{}{"name": "Sales Team", "description": "sales"},
+	})
+	appID := s.Put("/apps", map[string]interface{}{
+		"name":          "bookmark",
+		"label":         "Sales Wiki",
+		"signOnMode":    "BOOKMARK",
+		"accessibility": map[string]interface{}{"selfService": false},
+		"visibility": map[string]interface{}{
+			"autoSubmitToolbar": false,
+			"hide":              map[string]interface{}{"iOS": false, "web": false},
+		},
+		"settings": map[string]interface{}{
+			"app": map[string]interface{}{"url": "https://wiki.example.com", "requestIntegration": false},
+		},
+	})
+	s.Put("/apps/"+appID+"/groups", map[string]interface{}{"id": groupID, "priority": 1})
+
+	dir := t.TempDir()
+	err := Export(context.Background(), ExportOptions{
+		Dir:   dir,
+		Types: []string{group, appBookmark, appGroupAssignments},
+	})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	// reads a file with its whitespace collapsed, so the expectations don't
+	// depend on the alignment of the attributes
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return strings.Join(strings.Fields(string(b)), " ")
+	}
+	groups := read("okta_group.tf")
+	for _, expected := range []string{`resource "okta_group" "sales_team" {`, `name = "Sales Team"`, `skip_users = true`} {
+		if !strings.Contains(groups, expected) {
+			t.Errorf("expected okta_group.tf to contain %q:\n%s", expected, groups)
+		}
+	}
+	apps := read("okta_app_bookmark.tf")
+	if !strings.Contains(apps, `resource "okta_app_bookmark" "sales_wiki" {`) || !strings.Contains(apps, `url = "https://wiki.example.com"`) {
+		t.Errorf("unexpected okta_app_bookmark.tf:\n%s", apps)
+	}
+	assignments := read("okta_app_group_assignments.tf")
+	for _, expected := range []string{"app_id = okta_app_bookmark.sales_wiki.id", "id = okta_group.sales_team.id"} {
+		if !strings.Contains(assignments, expected) {
+			t.Errorf("expected the IDs to be references, %q missing from:\n%s", expected, assignments)
+		}
+	}
+	imports := read("imports.tf")
+	for _, expected := range []string{
+		"to = okta_group.sales_team",
+		`id = "` + groupID + `/skip_users"`,
+		`id = "` + appID + `/skip_users/skip_groups"`,
+		"to = okta_app_group_assignments.sales_wiki",
+	} {
+		if !strings.Contains(imports, expected) {
+			t.Errorf("expected imports.tf to contain %q:\n%s", expected, imports)
+		}
+	}
+
+	if err := Export(context.Background(), ExportOptions{Dir: dir, Types: []string{"okta_network_zone"}}); err == nil {
+		t.Errorf("expected an unsupported resource type to fail")
+	}
+}
+
+func TestUnexportableResourceTypes(t *testing.T) {
+	unexportable := UnexportableResourceTypes()
+	for _, name := range []string{networkZone, logStream} {
+		if !contains(unexportable, name) {
+			t.Errorf("expected %q to be reported as not exportable", name)
+		}
+	}
+	for _, name := range ExportableResourceTypes() {
+		if contains(unexportable, name) {
+			t.Errorf("expected %q not to be reported as not exportable", name)
+		}
+	}
+	if contains(unexportable, "okta_idp") {
+		t.Errorf("expected deprecated resource types not to be reported")
+	}
+}
@@ -0,0 +1,740 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+)
+
+const (
+	fakeOktaAPIPrefix        = "/api/v1/"
+	fakeOktaClientsPrefix    = "/oauth2/v1/clients/"
+	fakeOktaDefaultLimit     = 200
+	fakeOktaDefaultRateLimit = 600
+)
+
+// fakeOktaCollections are the top level management API collections the fake
+// server knows about, and the prefix of the IDs it generates for them.
+var fakeOktaCollections = map[string]string{
+	"apps":                 "0oa",
+	"authorizationServers": "aus",
+	"device-assurances":    "dae",
+	"eventHooks":           "who",
+	"groups":               "00g",
+	"idps":                 "0oi",
+	"inlineHooks":          "cal",
+	"logStreams":           "0oa",
+	"logs":                 "log",
+	"policies":             "00p",
+	"users":                "00u",
+}
+
+// fakeOktaServer is a stateful, in-process stand-in for the subset of the Okta
+// management API the provider uses for users, groups, apps, policies and their
+// rules, authorization servers, device assurance policies, identity providers,
+// hooks, log streams, the System Log and the roles of OAuth clients. Objects
+// are stored as they are sent, lists are paginated with Link headers and every
+// response carries x-rate-limit-* headers.
+//
+// Configure the provider with its URL as the http_proxy to run create, read,
+// update and delete lifecycles without an Okta org.
+type fakeOktaServer struct {
+	*httptest.Server
+	lock        sync.Mutex
+	collections map[string]*fakeOktaCollection
+	nextID      int
+	rateLimit   int
+	remaining   int
+	reset       int64
+	requests    []string
+}
+
+type fakeOktaCollection struct {
+	ids   []string
+	items map[string]map[string]interface{}
+}
+
+// newFakeOktaServer starts a fake Okta server and points the provider's ENV
+// config at it for the duration of the test.
+func newFakeOktaServer(t *testing.T) *fakeOktaServer {
+	s := &fakeOktaServer{
+		collections: make(map[string]*fakeOktaCollection),
+		rateLimit:   fakeOktaDefaultRateLimit,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	t.Setenv("OKTA_HTTP_PROXY", s.URL)
+	t.Setenv("OKTA_ORG_NAME", "fake-org")
+	t.Setenv("OKTA_API_TOKEN", "fake-token")
+	t.Setenv("OKTA_ACCESS_TOKEN", "")
+	t.Setenv("OKTA_API_CLIENT_ID", "")
+	t.Setenv("OKTA_API_PRIVATE_KEY", "")
+	t.Setenv("OKTA_API_PRIVATE_KEY_ID", "")
+	t.Setenv("OKTA_API_SCOPES", "")
+	return s
+}
+
+// Put stores an object directly, bypassing the API. It is used by tests to
+// seed data or to simulate changes made outside of Terraform.
+func (s *fakeOktaServer) Put(collectionPath string, obj map[string]interface{}) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.create(collectionPath, obj, true)
+}
+
+// Get returns the object at the given path, nil if it does not exist.
+func (s *fakeOktaServer) Get(itemPath string) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	collectionPath, id := path2(itemPath)
+	c, ok := s.collections[collectionPath]
+	if !ok {
+		return nil
+	}
+	return c.items[id]
+}
+
+// Delete removes the object at the given path directly, bypassing the API.
+func (s *fakeOktaServer) Delete(itemPath string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	collectionPath, id := path2(itemPath)
+	s.remove(collectionPath, id)
+}
+
+// Requests returns the "METHOD /path" of every request the server handled.
+func (s *fakeOktaServer) Requests() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+func (s *fakeOktaServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	s.setRateLimitHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/.well-known/okta-organization" {
+		s.write(w, http.StatusOK, map[string]interface{}{
+			"id":       "00o1fakeorg",
+			"pipeline": "idx",
+			"_links":   map[string]interface{}{"organization": map[string]interface{}{"href": s.URL}},
+		})
+		return
+	}
+	path := r.URL.Path
+	// the client ID of an OAuth app is its ID, so the roles assigned to the
+	// client are the ones of /api/v1/apps/${id}/roles
+	if strings.HasPrefix(path, fakeOktaClientsPrefix) {
+		path = fakeOktaAPIPrefix + "apps/" + strings.TrimPrefix(path, fakeOktaClientsPrefix)
+	}
+	if !strings.HasPrefix(path, fakeOktaAPIPrefix) {
+		s.notFound(w, r.URL.Path)
+		return
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, fakeOktaAPIPrefix), "/"), "/")
+	if _, ok := fakeOktaCollections[segments[0]]; !ok {
+		s.notFound(w, r.URL.Path)
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPut) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err.Error() != "EOF" {
+			s.error(w, http.StatusBadRequest, "E0000003", fmt.Sprintf("The request body was not well-formed: %v", err))
+			return
+		}
+	}
+
+	// lifecycle operations, e.g. POST /api/v1/apps/${id}/lifecycle/activate
+	if i := indexOf(segments, "lifecycle"); i > 0 && i == len(segments)-2 {
+		s.lifecycle(w, segments[:i], segments[i+1])
+		return
+	}
+
+	// GET /api/v1/users/me
+	if len(segments) == 2 && segments[0] == "users" && segments[1] == "me" && r.Method == http.MethodGet {
+		s.write(w, http.StatusOK, map[string]interface{}{
+			"id":      "00u1fakeadmin",
+			"status":  statusActive,
+			"profile": map[string]interface{}{"login": "admin@example.com", "email": "admin@example.com"},
+		})
+		return
+	}
+
+	// GET /api/v1/users/${id}/groups is derived from the group memberships
+	if len(segments) == 3 && segments[0] == "users" && segments[2] == "groups" && r.Method == http.MethodGet {
+		s.list(w, r, s.userGroups(segments[1]))
+		return
+	}
+
+	if len(segments)%2 == 1 {
+		collectionPath := "/" + strings.Join(segments, "/")
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w, r, s.filter(collectionPath, r.URL.Query()))
+		case http.MethodPost:
+			if body == nil {
+				body = map[string]interface{}{}
+			}
+			id := s.create(collectionPath, body, r.URL.Query().Get("activate") != "false")
+			s.write(w, http.StatusOK, s.collections[collectionPath].items[id])
+		default:
+			s.error(w, http.StatusMethodNotAllowed, "E0000022", "The endpoint does not support the provided HTTP method")
+		}
+		return
+	}
+
+	collectionPath := "/" + strings.Join(segments[:len(segments)-1], "/")
+	id := segments[len(segments)-1]
+	parent := s.parentExists(segments)
+	if !parent {
+		s.notFound(w, r.URL.Path)
+		return
+	}
+	c := s.collection(collectionPath)
+	item, exists := c.items[id]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			s.notFound(w, r.URL.Path)
+			return
+		}
+		s.write(w, http.StatusOK, item)
+	case http.MethodPut, http.MethodPost:
+		if !exists {
+			if len(segments) == 2 {
+				s.notFound(w, r.URL.Path)
+				return
+			}
+			// assignments such as PUT /api/v1/groups/${groupId}/users/${userId}
+			// reference an object that lives in its own collection
+			item = s.reference(segments[len(segments)-2], id)
+			if item == nil {
+				s.notFound(w, r.URL.Path)
+				return
+			}
+			c.ids = append(c.ids, id)
+			c.items[id] = item
+		}
+		if len(body) > 0 {
+			if r.Method == http.MethodPut {
+				for k := range item {
+					if k != "id" && k != "created" && k != "status" && k != "_links" {
+						delete(item, k)
+					}
+				}
+			}
+			mergeFakeOktaObject(item, body)
+		}
+		item["lastUpdated"] = time.Now().UTC().Format(time.RFC3339)
+		if r.Method == http.MethodPut && !exists && len(body) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		s.write(w, http.StatusOK, item)
+	case http.MethodDelete:
+		if !exists {
+			s.notFound(w, r.URL.Path)
+			return
+		}
+		// deleting an active user only deprovisions it, like the real API
+		if len(segments) == 2 && segments[0] == "users" && item["status"] != "DEPROVISIONED" {
+			item["status"] = "DEPROVISIONED"
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		s.remove(collectionPath, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "E0000022", "The endpoint does not support the provided HTTP method")
+	}
+}
+
+func (s *fakeOktaServer) lifecycle(w http.ResponseWriter, itemSegments []string, operation string) {
+	if len(itemSegments)%2 != 0 || !s.parentExists(itemSegments) {
+		s.notFound(w, strings.Join(itemSegments, "/"))
+		return
+	}
+	collectionPath := "/" + strings.Join(itemSegments[:len(itemSegments)-1], "/")
+	item, ok := s.collection(collectionPath).items[itemSegments[len(itemSegments)-1]]
+	if !ok {
+		s.notFound(w, strings.Join(itemSegments, "/"))
+		return
+	}
+	switch operation {
+	case "activate", "unsuspend", "unlock":
+		item["status"] = statusActive
+	case "deactivate":
+		item["status"] = statusInactive
+	case "suspend":
+		item["status"] = "SUSPENDED"
+	case "verify":
+		item["verificationStatus"] = "VERIFIED"
+	}
+	item["lastUpdated"] = time.Now().UTC().Format(time.RFC3339)
+	s.write(w, http.StatusOK, item)
+}
+
+func (s *fakeOktaServer) create(collectionPath string, obj map[string]interface{}, activate bool) string {
+	c := s.collection(collectionPath)
+	id, _ := obj["id"].(string)
+	if id == "" {
+		s.nextID++
+		segments := strings.Split(strings.Trim(collectionPath, "/"), "/")
+		prefix, ok := fakeOktaCollections[segments[len(segments)-1]]
+		if !ok {
+			prefix = "0fk"
+		}
+		id = fmt.Sprintf("%s%017d", prefix, s.nextID)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	item := map[string]interface{}{
+		"id":          id,
+		"created":     now,
+		"lastUpdated": now,
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": s.URL + "/api/v1" + collectionPath + "/" + id},
+		},
+	}
+	if activate {
+		item["status"] = statusActive
+	} else {
+		item["status"] = statusInactive
+		if strings.HasSuffix(collectionPath, "/users") {
+			item["status"] = "STAGED"
+		}
+	}
+	if strings.HasSuffix(collectionPath, "/groups") {
+		item["type"] = "OKTA_GROUP"
+	}
+	mergeFakeOktaObject(item, obj)
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = item
+	return id
+}
+
+func (s *fakeOktaServer) remove(collectionPath, id string) {
+	c, ok := s.collections[collectionPath]
+	if !ok {
+		return
+	}
+	delete(c.items, id)
+	for i := range c.ids {
+		if c.ids[i] == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	// nested collections go with their parent, as do the assignments of a
+	// user or group elsewhere
+	itemPath := collectionPath + "/" + id
+	for k := range s.collections {
+		if strings.HasPrefix(k, itemPath+"/") {
+			delete(s.collections, k)
+		}
+	}
+	if collectionPath == "/users" || collectionPath == "/groups" {
+		for k, nested := range s.collections {
+			if strings.HasSuffix(k, collectionPath) && k != collectionPath {
+				if _, ok := nested.items[id]; ok {
+					s.remove(k, id)
+				}
+			}
+		}
+	}
+}
+
+func (s *fakeOktaServer) collection(collectionPath string) *fakeOktaCollection {
+	c, ok := s.collections[collectionPath]
+	if !ok {
+		c = &fakeOktaCollection{items: make(map[string]map[string]interface{})}
+		s.collections[collectionPath] = c
+	}
+	return c
+}
+
+// parentExists checks that every object on the path above the last segment
+// exists, e.g. the policy of /policies/${policyId}/rules/${ruleId}
+func (s *fakeOktaServer) parentExists(segments []string) bool {
+	for i := 1; i < len(segments)-1; i += 2 {
+		c, ok := s.collections["/"+strings.Join(segments[:i], "/")]
+		if !ok {
+			return false
+		}
+		if _, ok := c.items[segments[i]]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *fakeOktaServer) reference(kind, id string) map[string]interface{} {
+	c, ok := s.collections["/"+kind]
+	if !ok {
+		return map[string]interface{}{"id": id}
+	}
+	item, ok := c.items[id]
+	if !ok {
+		return nil
+	}
+	ref := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		ref[k] = v
+	}
+	return ref
+}
+
+func (s *fakeOktaServer) userGroups(userID string) []map[string]interface{} {
+	var result []map[string]interface{}
+	groups, ok := s.collections["/groups"]
+	if !ok {
+		return result
+	}
+	for _, id := range groups.ids {
+		members, ok := s.collections["/groups/"+id+"/users"]
+		if !ok {
+			continue
+		}
+		if _, ok := members.items[userID]; ok {
+			result = append(result, groups.items[id])
+		}
+	}
+	return result
+}
+
+// filter returns the items of a collection that match the q, search, type and
+// after query parameters of a list request.
+func (s *fakeOktaServer) filter(collectionPath string, qp url.Values) []map[string]interface{} {
+	var result []map[string]interface{}
+	c, ok := s.collections[collectionPath]
+	if !ok {
+		return result
+	}
+	q := strings.ToLower(qp.Get("q"))
+	typ := qp.Get("type")
+	filter := fakeOktaFilter(qp.Get("filter"))
+	for _, id := range c.ids {
+		item := c.items[id]
+		if typ != "" && item["type"] != typ {
+			continue
+		}
+		if q != "" && !fakeOktaObjectMatches(item, q) {
+			continue
+		}
+		if !filter(item) {
+			continue
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// fakeOktaFilter supports the filters made of `attribute eq "value"`
+// expressions joined with "and", e.g. `type eq "aws_eventbridge"`.
+func fakeOktaFilter(filter string) func(item map[string]interface{}) bool {
+	expected := map[string]string{}
+	for _, expr := range strings.Split(filter, " and ") {
+		parts := strings.SplitN(strings.TrimSpace(expr), " eq ", 2)
+		if len(parts) == 2 {
+			expected[parts[0]] = strings.Trim(parts[1], `"`)
+		}
+	}
+	return func(item map[string]interface{}) bool {
+		for k, v := range expected {
+			if fmt.Sprint(item[k]) != v {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func (s *fakeOktaServer) list(w http.ResponseWriter, r *http.Request, items []map[string]interface{}) {
+	qp := r.URL.Query()
+	limit, err := strconv.Atoi(qp.Get("limit"))
+	if err != nil || limit < 1 {
+		limit = fakeOktaDefaultLimit
+	}
+	start := 0
+	if after := qp.Get("after"); after != "" {
+		for i := range items {
+			if items[i]["id"] == after {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	page := items[start:end]
+	if page == nil {
+		page = []map[string]interface{}{}
+	}
+
+	self := *r.URL
+	self.Scheme, self.Host = "http", r.Host
+	links := []string{fmt.Sprintf("<%s>; rel=\"self\"", self.String())}
+	// the System Log is polled when until isn't set, its last page has a next
+	// link as well
+	polling := r.URL.Path == fakeOktaAPIPrefix+"logs" && qp.Get("until") == ""
+	if end < len(items) || polling {
+		next := self
+		nextQP := next.Query()
+		if len(page) > 0 {
+			nextQP.Set("after", page[len(page)-1]["id"].(string))
+		}
+		nextQP.Set("limit", strconv.Itoa(limit))
+		next.RawQuery = nextQP.Encode()
+		links = append(links, fmt.Sprintf("<%s>; rel=\"next\"", next.String()))
+	}
+	for _, link := range links {
+		w.Header().Add("Link", link)
+	}
+	s.write(w, http.StatusOK, page)
+}
+
+func (s *fakeOktaServer) setRateLimitHeaders(w http.ResponseWriter) {
+	now := time.Now().Unix()
+	if now >= s.reset {
+		s.reset = now + 60
+		s.remaining = s.rateLimit
+	}
+	if s.remaining > 0 {
+		s.remaining--
+	}
+	w.Header().Set("x-rate-limit-limit", strconv.Itoa(s.rateLimit))
+	w.Header().Set("x-rate-limit-remaining", strconv.Itoa(s.remaining))
+	w.Header().Set("x-rate-limit-reset", strconv.FormatInt(s.reset, 10))
+}
+
+func (s *fakeOktaServer) write(w http.ResponseWriter, status int, v interface{}) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *fakeOktaServer) notFound(w http.ResponseWriter, path string) {
+	s.error(w, http.StatusNotFound, "E0000007", fmt.Sprintf("Not found: Resource not found: %s", path))
+}
+
+func (s *fakeOktaServer) error(w http.ResponseWriter, status int, code, summary string) {
+	s.write(w, status, map[string]interface{}{
+		"errorCode":    code,
+		"errorSummary": summary,
+		"errorLink":    code,
+		"errorId":      "oaeFakeOktaServer",
+		"errorCauses":  []interface{}{},
+	})
+}
+
+func mergeFakeOktaObject(dst, src map[string]interface{}) {
+	for k, v := range src {
+		if k == "id" {
+			continue
+		}
+		srcMap, srcIsMap := v.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeFakeOktaObject(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func fakeOktaObjectMatches(item map[string]interface{}, q string) bool {
+	candidates := []interface{}{item["name"], item["label"]}
+	if profile, ok := item["profile"].(map[string]interface{}); ok {
+		candidates = append(candidates, profile["name"], profile["login"], profile["email"], profile["firstName"], profile["lastName"])
+	}
+	for _, c := range candidates {
+		if s, ok := c.(string); ok && strings.HasPrefix(strings.ToLower(s), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// path2 splits "/groups/${id}" into its collection path and ID.
+func path2(itemPath string) (string, string) {
+	i := strings.LastIndex(itemPath, "/")
+	return itemPath[:i], itemPath[i+1:]
+}
+
+func indexOf(segments []string, s string) int {
+	for i := range segments {
+		if segments[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestFakeOktaServerPagination(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	for i := 0; i < 5; i++ {
+		srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": fmt.Sprintf("testAcc_%d", i)}})
+	}
+	srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": "other"}})
+
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	groups, err := listGroups(context.Background(), config.oktaClient, &query.Params{Q: "testAcc", Limit: 2})
+	if err != nil {
+		t.Fatalf("failed to list groups: %v", err)
+	}
+	if len(groups) != 5 {
+		t.Fatalf("expected 5 groups across all pages, got %d", len(groups))
+	}
+	var pages int
+	for _, r := range srv.Requests() {
+		if r == "GET /api/v1/groups" {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Fatalf("expected 3 pages to be requested, got %d", pages)
+	}
+}
+
+func TestFakeOktaServerRateLimitHeaders(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	resp, err := http.Get(srv.URL + "/api/v1/users")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("x-rate-limit-limit") != strconv.Itoa(fakeOktaDefaultRateLimit) {
+		t.Errorf("expected x-rate-limit-limit header, got %q", resp.Header.Get("x-rate-limit-limit"))
+	}
+	if resp.Header.Get("x-rate-limit-remaining") != strconv.Itoa(fakeOktaDefaultRateLimit-1) {
+		t.Errorf("expected x-rate-limit-remaining header, got %q", resp.Header.Get("x-rate-limit-remaining"))
+	}
+	if resp.Header.Get("x-rate-limit-reset") == "" {
+		t.Errorf("expected x-rate-limit-reset header")
+	}
+}
+
+func TestFakeOktaServerGroupDrift(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	ctx := context.Background()
+	r := resourceGroup()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "testAcc_drift",
+		"description": "created",
+	})
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to create group: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected group ID to be set")
+	}
+
+	// a change made outside of Terraform is picked up on read
+	srv.Get("/groups/" + d.Id())["profile"].(map[string]interface{})["description"] = "changed"
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read group: %v", diags)
+	}
+	if d.Get("description") != "changed" {
+		t.Fatalf("expected description drift to be read, got %q", d.Get("description"))
+	}
+
+	// so is a group that has been deleted outside of Terraform
+	srv.Delete("/groups/" + d.Id())
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read group: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected group ID to be cleared when the group is gone, got %q", d.Id())
+	}
+}
+
+func TestFakeOktaServerUserLifecycle(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	ctx := context.Background()
+	client := config.oktaClient
+	profile := okta.UserProfile{"login": "testAcc@example.com", "email": "testAcc@example.com", "firstName": "Test", "lastName": "Acc"}
+	u, _, err := client.User.CreateUser(ctx, okta.CreateUserRequest{Profile: &profile}, nil)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	g, _, err := client.Group.CreateGroup(ctx, okta.Group{Profile: &okta.GroupProfile{Name: "testAcc_group"}})
+	if err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	if _, err := client.Group.AddUserToGroup(ctx, g.Id, u.Id); err != nil {
+		t.Fatalf("failed to add user to group: %v", err)
+	}
+	groups, _, err := client.User.ListUserGroups(ctx, u.Id)
+	if err != nil || len(groups) != 1 || groups[0].Id != g.Id {
+		t.Fatalf("expected user to be member of group %q, got %+v, %v", g.Id, groups, err)
+	}
+
+	if err := ensureUserDelete(ctx, u.Id, u.Status, client); err != nil {
+		t.Fatalf("failed to delete user: %v", err)
+	}
+	if srv.Get("/users/"+u.Id) != nil {
+		t.Fatal("expected user to be deleted")
+	}
+	users, _, err := client.Group.ListGroupUsers(ctx, g.Id, nil)
+	if err != nil || len(users) != 0 {
+		t.Fatalf("expected deleted user to be removed from group, got %+v, %v", users, err)
+	}
+}
+
+// TestFakeOktaServerGroupResource runs a complete Terraform lifecycle of an
+// okta_group against the fake server, it only needs TF_ACC and a terraform
+// binary.
+func TestFakeOktaServerGroupResource(t *testing.T) {
+	newFakeOktaServer(t)
+	resourceName := fmt.Sprintf("%s.test", group)
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProvidersFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "okta_group" "test" { name = "testAcc_fake" }`,
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", "testAcc_fake"),
+			},
+			{
+				Config: `resource "okta_group" "test" {
+  name        = "testAcc_fake_updated"
+  description = "updated"
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "testAcc_fake_updated"),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated"),
+				),
+			},
+		},
+	})
+}
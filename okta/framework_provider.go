@@ -0,0 +1,208 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns the server of the provider, which serves the
+// resources and data sources of the SDK provider and of the framework one.
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return newProviderServer(ctx, Provider())
+}
+
+// newProviderServer muxes the SDK provider with the framework provider in a
+// single protocol v5 server. The SDK provider comes first so it is configured
+// first, the framework provider takes its Config.
+func newProviderServer(ctx context.Context, p *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	framework := providerserver.NewProtocol5(&frameworkProvider{sdkProvider: p})
+	server, err := tf5muxserver.NewMuxServer(ctx, p.GRPCProvider, func() tfprotov5.ProviderServer {
+		return frameworkProviderServer{framework()}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server.ProviderServer, nil
+}
+
+// frameworkProviderServer leaves the prepared provider config to the SDK
+// provider, which sets the defaults of the attributes in it. The mux fails when
+// the servers prepare configs which aren't the same.
+type frameworkProviderServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s frameworkProviderServer) PrepareProviderConfig(ctx context.Context, req *tfprotov5.PrepareProviderConfigRequest) (*tfprotov5.PrepareProviderConfigResponse, error) {
+	resp, err := s.ProviderServer.PrepareProviderConfig(ctx, req)
+	if resp != nil {
+		resp.PreparedConfig = nil
+	}
+	return resp, err
+}
+
+// frameworkProvider is the provider of the resources and data sources written
+// with terraform-plugin-framework, for the settings which are better served by
+// its typed values and blocks than by the SDK. Protocol version 5 has no nested
+// attributes, nested settings are blocks. Its schema and configuration are the
+// ones of the SDK provider it is muxed with.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+	config      *Config
+}
+
+func (p *frameworkProvider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	resp, err := p.sdkProvider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		diags.AddError("Failed to get the provider schema", err.Error())
+		return tfsdk.Schema{}, diags
+	}
+	s, err := frameworkSchema(resp.Provider)
+	if err != nil {
+		diags.AddError("Failed to convert the provider schema", err.Error())
+	}
+	return s, diags
+}
+
+func (p *frameworkProvider) Configure(_ context.Context, _ tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
+	config, ok := p.sdkProvider.Meta().(*Config)
+	if !ok {
+		resp.Diagnostics.AddError("Provider not configured", "The provider has to be configured before the framework provider.")
+		return
+	}
+	p.config = config
+}
+
+func (p *frameworkProvider) GetResources(context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
+	return map[string]tfsdk.ResourceType{
+		deviceAssurancePolicy: deviceAssurancePolicyResourceType{},
+		logStream:             logStreamResourceType{},
+	}, nil
+}
+
+func (p *frameworkProvider) GetDataSources(context.Context) (map[string]tfsdk.DataSourceType, diag.Diagnostics) {
+	return map[string]tfsdk.DataSourceType{
+		logStreams: logStreamsDataSourceType{},
+	}, nil
+}
+
+// frameworkSchema converts the protocol schema of the SDK provider to the
+// framework one, so the framework provider has the same schema.
+func frameworkSchema(s *tfprotov5.Schema) (tfsdk.Schema, error) {
+	attributes, blocks, err := frameworkBlock(s.Block)
+	if err != nil {
+		return tfsdk.Schema{}, err
+	}
+	result := tfsdk.Schema{
+		Version:    s.Version,
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+	result.Description, result.MarkdownDescription = frameworkDescription(s.Block.Description, s.Block.DescriptionKind)
+	if s.Block.Deprecated {
+		result.DeprecationMessage = "Deprecated"
+	}
+	return result, nil
+}
+
+func frameworkBlock(b *tfprotov5.SchemaBlock) (map[string]tfsdk.Attribute, map[string]tfsdk.Block, error) {
+	attributes := make(map[string]tfsdk.Attribute, len(b.Attributes))
+	for _, a := range b.Attributes {
+		t, err := frameworkType(a.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("attribute %s: %v", a.Name, err)
+		}
+		attribute := tfsdk.Attribute{
+			Type:      t,
+			Required:  a.Required,
+			Optional:  a.Optional,
+			Computed:  a.Computed,
+			Sensitive: a.Sensitive,
+		}
+		attribute.Description, attribute.MarkdownDescription = frameworkDescription(a.Description, a.DescriptionKind)
+		if a.Deprecated {
+			attribute.DeprecationMessage = "Deprecated"
+		}
+		attributes[a.Name] = attribute
+	}
+	var blocks map[string]tfsdk.Block
+	if len(b.BlockTypes) > 0 {
+		blocks = make(map[string]tfsdk.Block, len(b.BlockTypes))
+	}
+	for _, nested := range b.BlockTypes {
+		blockAttributes, blockBlocks, err := frameworkBlock(nested.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("block %s: %v", nested.TypeName, err)
+		}
+		block := tfsdk.Block{
+			Attributes: blockAttributes,
+			Blocks:     blockBlocks,
+			MinItems:   nested.MinItems,
+			MaxItems:   nested.MaxItems,
+		}
+		switch nested.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			block.NestingMode = tfsdk.BlockNestingModeList
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			block.NestingMode = tfsdk.BlockNestingModeSet
+		default:
+			return nil, nil, fmt.Errorf("block %s: unsupported nesting mode %v", nested.TypeName, nested.Nesting)
+		}
+		block.Description, block.MarkdownDescription = frameworkDescription(nested.Block.Description, nested.Block.DescriptionKind)
+		if nested.Block.Deprecated {
+			block.DeprecationMessage = "Deprecated"
+		}
+		blocks[nested.TypeName] = block
+	}
+	return attributes, blocks, nil
+}
+
+func frameworkDescription(description string, kind tfprotov5.StringKind) (string, string) {
+	if kind == tfprotov5.StringKindMarkdown {
+		return "", description
+	}
+	return description, ""
+}
+
+func frameworkType(t tftypes.Type) (attr.Type, error) {
+	switch t := t.(type) {
+	case tftypes.List:
+		elem, err := frameworkType(t.ElementType)
+		return types.ListType{ElemType: elem}, err
+	case tftypes.Set:
+		elem, err := frameworkType(t.ElementType)
+		return types.SetType{ElemType: elem}, err
+	case tftypes.Map:
+		elem, err := frameworkType(t.ElementType)
+		return types.MapType{ElemType: elem}, err
+	case tftypes.Object:
+		attrTypes := make(map[string]attr.Type, len(t.AttributeTypes))
+		for name, attrType := range t.AttributeTypes {
+			converted, err := frameworkType(attrType)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = converted
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
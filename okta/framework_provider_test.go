@@ -0,0 +1,80 @@
+package okta
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServer(t *testing.T) {
+	newFakeOktaServer(t)
+	ctx := context.Background()
+	p := Provider()
+	providerServer, err := newProviderServer(ctx, p)
+	if err != nil {
+		t.Fatalf("failed to mux the providers: %v", err)
+	}
+	server := providerServer()
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := schemaResp.ResourceSchemas[user]; !ok {
+		t.Errorf("expected the resources of the SDK provider to be served")
+	}
+
+	// every attribute is left to its default, the fake server is set through
+	// the environment
+	typ := schemaResp.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prepareResp, err := server.PrepareProviderConfig(ctx, &tfprotov5.PrepareProviderConfigRequest{Config: &config})
+	if err != nil {
+		t.Fatalf("failed to prepare the provider config: %v", err)
+	}
+	if len(prepareResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics preparing the provider config: %+v", prepareResp.Diagnostics[0])
+	}
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: prepareResp.PreparedConfig})
+	if err != nil {
+		t.Fatalf("failed to configure the provider: %v", err)
+	}
+	if len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics configuring the provider: %+v", configureResp.Diagnostics[0])
+	}
+	if _, ok := p.Meta().(*Config); !ok {
+		t.Fatalf("expected the SDK provider to be configured")
+	}
+}
+
+func TestFrameworkProviderConfigure(t *testing.T) {
+	p := Provider()
+	framework := &frameworkProvider{sdkProvider: p}
+
+	var resp tfsdk.ConfigureProviderResponse
+	framework.Configure(context.Background(), tfsdk.ConfigureProviderRequest{}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error when the SDK provider isn't configured")
+	}
+
+	config := &Config{}
+	p.SetMeta(config)
+	resp = tfsdk.ConfigureProviderResponse{}
+	framework.Configure(context.Background(), tfsdk.ConfigureProviderRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error configuring the framework provider: %v", resp.Diagnostics)
+	}
+	if framework.config != config {
+		t.Errorf("expected the framework provider to take the config of the SDK provider")
+	}
+}
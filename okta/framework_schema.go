@@ -0,0 +1,185 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The validators and plan modifiers of the framework resources, which are the
+// counterparts of the SDK ones the other resources use.
+
+// stringOneOf validates a string attribute is one of the values, like
+// elemInSlice.
+func stringOneOf(values ...string) tfsdk.AttributeValidator {
+	return stringOneOfValidator(values)
+}
+
+type stringOneOfValidator []string
+
+func (v stringOneOfValidator) Description(context.Context) string {
+	return fmt.Sprintf("value must be one of '%s'", strings.Join(v, "', '"))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var s types.String
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &s)...)
+	if resp.Diagnostics.HasError() || s.Null || s.Unknown {
+		return
+	}
+	for _, value := range v {
+		if s.Value == value {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid value",
+		fmt.Sprintf("expected value to be one of '%s', got '%s'", strings.Join(v, "', '"), s.Value))
+}
+
+// stringSetOneOf validates every element of a set of strings attribute is one
+// of the values.
+func stringSetOneOf(values ...string) tfsdk.AttributeValidator {
+	return stringSetOneOfValidator(values)
+}
+
+type stringSetOneOfValidator []string
+
+func (v stringSetOneOfValidator) Description(context.Context) string {
+	return fmt.Sprintf("values must be one of '%s'", strings.Join(v, "', '"))
+}
+
+func (v stringSetOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringSetOneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var set types.Set
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &set)...)
+	if resp.Diagnostics.HasError() || set.Null || set.Unknown {
+		return
+	}
+	for _, elem := range set.Elems {
+		s, ok := elem.(types.String)
+		if !ok || s.Null || s.Unknown {
+			continue
+		}
+		if !contains(v, s.Value) {
+			resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid value",
+				fmt.Sprintf("expected values to be one of '%s', got '%s'", strings.Join(v, "', '"), s.Value))
+		}
+	}
+}
+
+// stringMatchesRegex validates a string attribute matches the regular
+// expression, name says what the value is, like stringMatches.
+func stringMatchesRegex(r *regexp.Regexp, name string) tfsdk.AttributeValidator {
+	return stringMatchesValidator{r: r, name: name}
+}
+
+type stringMatchesValidator struct {
+	r    *regexp.Regexp
+	name string
+}
+
+func (v stringMatchesValidator) Description(context.Context) string {
+	return fmt.Sprintf("value must be a valid %s", v.name)
+}
+
+func (v stringMatchesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringMatchesValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var s types.String
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &s)...)
+	if resp.Diagnostics.HasError() || s.Null || s.Unknown {
+		return
+	}
+	if !v.r.MatchString(s.Value) {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid value",
+			fmt.Sprintf("%s is not a valid %s", req.AttributePath, v.name))
+	}
+}
+
+// stringDefault sets an optional and computed string attribute to the value
+// when it isn't configured, like the Default of the SDK.
+func stringDefault(value string) tfsdk.AttributePlanModifier {
+	return stringDefaultModifier(value)
+}
+
+type stringDefaultModifier string
+
+func (m stringDefaultModifier) Description(context.Context) string {
+	return fmt.Sprintf("defaults to '%s'", string(m))
+}
+
+func (m stringDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m stringDefaultModifier) Modify(_ context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	if req.AttributeConfig == nil || !req.AttributeConfig.IsNull() {
+		return
+	}
+	resp.AttributePlan = types.String{Value: string(m)}
+}
+
+// stringValue is the value of a string the API returns, which is null when the
+// string is empty as the API leaves out the unset attributes.
+func stringValue(s string) types.String {
+	if s == "" {
+		return types.String{Null: true}
+	}
+	return types.String{Value: s}
+}
+
+// stringSetValue is the value of a list of strings the API returns, which is
+// null when the list is empty.
+func stringSetValue(values []string) types.Set {
+	if len(values) == 0 {
+		return types.Set{ElemType: types.StringType, Null: true}
+	}
+	elems := make([]attr.Value, len(values))
+	for i, v := range values {
+		elems[i] = types.String{Value: v}
+	}
+	return types.Set{ElemType: types.StringType, Elems: elems}
+}
+
+// boolValue is the value of an optional bool the API returns.
+func boolValue(b *bool) types.Bool {
+	if b == nil {
+		return types.Bool{Null: true}
+	}
+	return types.Bool{Value: *b}
+}
+
+// stringSetElems are the strings of a set of strings attribute, nil when it is
+// null.
+func stringSetElems(set types.Set) []string {
+	var values []string
+	for _, elem := range set.Elems {
+		if s, ok := elem.(types.String); ok && !s.Null && !s.Unknown {
+			values = append(values, s.Value)
+		}
+	}
+	return values
+}
+
+// boolPointer is the value of an optional bool attribute for the API, nil when
+// it is null.
+func boolPointer(b types.Bool) *bool {
+	if b.Null || b.Unknown {
+		return nil
+	}
+	return boolPtr(b.Value)
+}
@@ -2,10 +2,13 @@ package okta
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/sdk"
 )
 
 func listGroupUsers(ctx context.Context, m interface{}, id string) ([]*okta.User, error) {
@@ -62,54 +65,131 @@ func listGroups(ctx context.Context, client *okta.Client, qp *query.Params) ([]*
 	return groups, nil
 }
 
-// Group Primary Key Operations (Use when # groups < # users in operations)
-func addGroupMembers(ctx context.Context, client *okta.Client, groupId string, users []string) error {
-	for _, user := range users {
-		resp, err := client.Group.AddUserToGroup(ctx, groupId, user)
-		exists, err := doesResourceExist(resp, err)
-		if err != nil {
-			return fmt.Errorf("failed to add user (%s) to group (%s): %w", user, groupId, err)
-		}
-		if !exists {
-			return fmt.Errorf("targeted object does not exist: %s", err)
-		}
+// groupMemberJobs returns the jobs adding the users to the group and removing
+// the users from it, the values of the jobs are the user IDs.
+func groupMemberJobs(client *okta.Client, groupId string, usersToAdd, usersToRemove []string) []setChangeJob {
+	jobs := make([]setChangeJob, 0, len(usersToAdd)+len(usersToRemove))
+	for _, user := range usersToAdd {
+		jobs = append(jobs, addGroupMemberJob(client, groupId, user, user))
 	}
-	return nil
+	for _, user := range usersToRemove {
+		jobs = append(jobs, removeGroupMemberJob(client, groupId, user, user))
+	}
+	return jobs
 }
 
-func removeGroupMembers(ctx context.Context, client *okta.Client, groupId string, users []string) error {
-	for _, user := range users {
-		resp, err := client.Group.RemoveUserFromGroup(ctx, groupId, user)
-		err = suppressErrorOn404(resp, err)
-		if err != nil {
-			return fmt.Errorf("failed to remove user (%s) from group (%s): %v", user, groupId, err)
-		}
+// userGroupJobs returns the jobs adding the user to the groups and removing
+// the user from them, the values of the jobs are the group IDs.
+func userGroupJobs(client *okta.Client, userId string, groupsToAdd, groupsToRemove []string) []setChangeJob {
+	jobs := make([]setChangeJob, 0, len(groupsToAdd)+len(groupsToRemove))
+	for _, group := range groupsToAdd {
+		jobs = append(jobs, addGroupMemberJob(client, group, userId, group))
+	}
+	for _, group := range groupsToRemove {
+		jobs = append(jobs, removeGroupMemberJob(client, group, userId, group))
 	}
-	return nil
+	return jobs
 }
 
-// User Primary Key Operations (use when # users < # groups in operations)
-func addUserToGroups(ctx context.Context, client *okta.Client, userId string, groups []string) error {
-	for _, group := range groups {
-		resp, err := client.Group.AddUserToGroup(ctx, group, userId)
-		exists, err := doesResourceExist(resp, err)
-		if err != nil {
-			return fmt.Errorf("failed to add user (%s) to group (%s): %v", userId, group, err)
-		}
-		if !exists {
-			return fmt.Errorf("targeted object does not exist: %s", err)
-		}
+func addGroupMemberJob(client *okta.Client, groupId, userId, value string) setChangeJob {
+	return setChangeJob{
+		job: job{
+			name: fmt.Sprintf("add user (%s) to group (%s)", userId, groupId),
+			run: func(ctx context.Context) error {
+				resp, err := client.Group.AddUserToGroup(ctx, groupId, userId)
+				exists, err := doesResourceExist(resp, err)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return errors.New("targeted object does not exist")
+				}
+				return nil
+			},
+		},
+		value: value,
+		add:   true,
 	}
-	return nil
 }
 
-func removeUserFromGroups(ctx context.Context, client *okta.Client, userId string, groups []string) error {
-	for _, group := range groups {
-		resp, err := client.Group.RemoveUserFromGroup(ctx, group, userId)
-		err = suppressErrorOn404(resp, err)
+func removeGroupMemberJob(client *okta.Client, groupId, userId, value string) setChangeJob {
+	return setChangeJob{
+		job: job{
+			name: fmt.Sprintf("remove user (%s) from group (%s)", userId, groupId),
+			run: func(ctx context.Context) error {
+				resp, err := client.Group.RemoveUserFromGroup(ctx, groupId, userId)
+				return suppressErrorOn404(resp, err)
+			},
+		},
+		value: value,
+	}
+}
+
+// listGroupOwners gets all the owners of the group, following the pages. The
+// response is the one of the last request, so a group that doesn't exist can
+// be told apart with is404.
+func listGroupOwners(ctx context.Context, client *sdk.APISupplement, groupId string) ([]*sdk.GroupOwner, *okta.Response, error) {
+	owners, resp, err := client.ListGroupOwners(ctx, groupId, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, resp, err
+	}
+	for resp.HasNextPage() {
+		var nextOwners []*sdk.GroupOwner
+		resp, err = resp.Next(ctx, &nextOwners)
 		if err != nil {
-			return fmt.Errorf("failed to remove user (%s) from group (%s): %v", userId, group, err)
+			return nil, resp, err
 		}
+		owners = append(owners, nextOwners...)
+	}
+	return owners, resp, nil
+}
+
+// groupOwnerJobs returns the jobs assigning the owners to the group and
+// unassigning the owners from it, the owners are the elements of the owner
+// set of okta_group_owners, with their id and type.
+func groupOwnerJobs(client *sdk.APISupplement, groupId string, ownersToAdd, ownersToRemove []interface{}) []setChangeJob {
+	jobs := make([]setChangeJob, 0, len(ownersToAdd)+len(ownersToRemove))
+	for _, owner := range ownersToAdd {
+		jobs = append(jobs, addGroupOwnerJob(client, groupId, owner.(map[string]interface{})))
+	}
+	for _, owner := range ownersToRemove {
+		jobs = append(jobs, removeGroupOwnerJob(client, groupId, owner.(map[string]interface{})))
+	}
+	return jobs
+}
+
+func addGroupOwnerJob(client *sdk.APISupplement, groupId string, owner map[string]interface{}) setChangeJob {
+	ownerId, ownerType := owner["id"].(string), owner["type"].(string)
+	return setChangeJob{
+		job: job{
+			name: fmt.Sprintf("assign %s (%s) as owner of group (%s)", strings.ToLower(ownerType), ownerId, groupId),
+			run: func(ctx context.Context) error {
+				_, resp, err := client.CreateGroupOwner(ctx, groupId, sdk.GroupOwner{ID: ownerId, Type: ownerType})
+				exists, err := doesResourceExist(resp, err)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return errors.New("targeted object does not exist")
+				}
+				return nil
+			},
+		},
+		value: owner,
+		add:   true,
+	}
+}
+
+func removeGroupOwnerJob(client *sdk.APISupplement, groupId string, owner map[string]interface{}) setChangeJob {
+	ownerId, ownerType := owner["id"].(string), owner["type"].(string)
+	return setChangeJob{
+		job: job{
+			name: fmt.Sprintf("unassign %s (%s) as owner of group (%s)", strings.ToLower(ownerType), ownerId, groupId),
+			run: func(ctx context.Context) error {
+				resp, err := client.DeleteGroupOwner(ctx, groupId, ownerId)
+				return suppressErrorOn404(resp, err)
+			},
+		},
+		value: owner,
 	}
-	return nil
 }
@@ -34,11 +34,48 @@ const (
 // API limits but it can account for its own usage and attempt to preemptively
 // react appropriately.
 type APIMutex struct {
-	lock     sync.Mutex
-	status   map[string]*APIStatus
-	capacity int
+	lock      sync.Mutex
+	status    map[string]*APIStatus
+	capacity  int
+	overrides []*limitOverride
+	// learned holds the class key of method + path templates that have been
+	// observed with a different limit than the class they normally belong to.
+	learned map[string]string
+	// candidates holds the method + path templates that have reported a
+	// different limit than their class but not often enough to be learned.
+	candidates map[string]*learnCandidate
+	// backend shares the statuses with the api mutexes of other processes, nil
+	// if the statuses are only known to this process.
+	backend Backend
+	// synced is when the backend was last synced and syncInterval how long
+	// reading a status goes without syncing it. Consume and Update always sync
+	// the backend, they run for every request anyway.
+	synced       time.Time
+	syncInterval time.Duration
 }
 
+// limitOverride is an org admin defined rate limit for the endpoints matching
+// the method and path regular expression. Matching endpoints are accounted for
+// in their own bucket rather than in one of Okta's default endpoint classes.
+type limitOverride struct {
+	method    string // empty matches any method
+	pathRegex *regexp.Regexp
+	class     string
+}
+
+// learnCandidate counts the consecutive responses of a method + path template
+// that reported the same limit, different from the limit of its class.
+type learnCandidate struct {
+	limit int
+	count int
+}
+
+// learnThreshold is the number of consecutive responses that must report the
+// same different limit before a method + path template gets its own bucket.
+// A single response is not enough as the limit of a class can legitimately
+// change, e.g. when the org admin raises it.
+const learnThreshold = 3
+
 // APIStatus is used to hold rate limit information from Okta's API, see:
 // https://developer.okta.com/docs/reference/rl-best-practices/
 type APIStatus struct {
@@ -46,6 +83,15 @@ type APIStatus struct {
 	remaining int
 	reset     int64 // UTC epoch time in seconds
 	class     string
+	override  int // admin defined limit, 0 if there is none
+}
+
+// Classes returns Okta's default endpoint classes.
+func Classes() []string {
+	return []string{
+		APPS_KEY, APPID_KEY, CAS_KEY, CLIENTS_KEY, DEVICES_KEY, EVENTS_KEY, GROUPS_KEY,
+		GROUPID_KEY, LOGS_KEY, USERS_KEY, USERID_KEY, USERME_KEY, USERIDGET_KEY, OTHER_KEY,
+	}
 }
 
 // NewAPIMutex returns a new api mutex object that represents untilized
@@ -71,11 +117,42 @@ func NewAPIMutex(capacity int) (*APIMutex, error) {
 		USERIDGET_KEY: {class: USERIDGET_KEY},
 	}
 	return &APIMutex{
-		capacity: capacity,
-		status:   status,
+		capacity:     capacity,
+		status:       status,
+		learned:      make(map[string]string),
+		candidates:   make(map[string]*learnCandidate),
+		syncInterval: time.Second,
 	}, nil
 }
 
+// AddOverride registers an admin defined rate limit for the endpoints matching
+// the method and path regular expression. Overrides are matched in the order
+// they were added and take precedence over the default endpoint classes.
+func (m *APIMutex) AddOverride(method, pathRegex string, limit int) error {
+	re, err := regexp.Compile(pathRegex)
+	if err != nil {
+		return fmt.Errorf("invalid rate limit override path regex %q: %v", pathRegex, err)
+	}
+	if limit < 1 {
+		return fmt.Errorf("expecting rate limit override limit as whole number > 0, was %d", limit)
+	}
+	method = strings.ToUpper(method)
+	class := fmt.Sprintf("override %s %s", method, pathRegex)
+	if method == "" {
+		class = fmt.Sprintf("override %s", pathRegex)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.overrides = append(m.overrides, &limitOverride{
+		method:    method,
+		pathRegex: re,
+		class:     class,
+	})
+	m.status[class] = &APIStatus{class: class, override: limit}
+	return nil
+}
+
 // HasCapacity approximates if there is capacity below the api mutex's maximum
 // capacity threshold.
 func (m *APIMutex) HasCapacity(method, endPoint string) bool {
@@ -86,8 +163,13 @@ func (m *APIMutex) HasCapacity(method, endPoint string) bool {
 		return true
 	}
 
-	// calculate utilization
-	utilization := 100.0 * (float32(status.limit-status.remaining) / float32(status.limit))
+	// calculate utilization, the requests used and the limit both have to come
+	// from the same bucket's numbers
+	budget := status.Budget()
+	if budget <= 0 {
+		return true
+	}
+	utilization := 100.0 * (float32(status.Used()) / float32(budget))
 
 	return utilization <= float32(m.capacity)
 }
@@ -101,44 +183,134 @@ func (m *APIMutex) Update(method, endPoint string, limit, remaining int, reset i
 	key := m.normalizeKey(method, endPoint)
 	status := m.status[key]
 
-	if reset > status.reset {
+	if m.learn(key, status, method, endPoint, limit, reset) {
+		key = m.normalizeKey(method, endPoint)
+		status = m.status[key]
+	}
+
+	m.sync(func() {
+		status.merge(limit, remaining, reset)
+	})
+}
+
+// Consume accounts for a request to the given API endpoint that is about to be
+// made, before its response reports the new remaining value. This lets other
+// processes sharing the backend see requests that are still in flight.
+func (m *APIMutex) Consume(method, endPoint string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.sync(func() {
+		status := m.status[m.normalizeKey(method, endPoint)]
+		if status.reset < time.Now().Unix() || status.remaining <= 0 {
+			// nothing is known about the current window
+			return
+		}
+		status.remaining--
+	})
+}
+
+// merge accounts for limit, remaining and reset values reported for the
+// status's endpoint class.
+func (s *APIStatus) merge(limit, remaining int, reset int64) {
+	if reset > s.reset {
 		// reset value greater than current reset implies we are in a new Okta API
 		// one minute window. set/reset values.
-		status.reset = reset
-		status.remaining = remaining
-		status.limit = limit
+		s.reset = reset
+		s.remaining = remaining
+		s.limit = limit
 		return
 	}
 
-	if reset <= (status.reset - 60) {
+	if reset <= (s.reset - 60) {
 		// these values are from the previous one minute window, ignore
 		return
 	}
 
-	if remaining < status.remaining {
-		status.remaining = remaining
+	if remaining < s.remaining {
+		s.remaining = remaining
+	}
+}
+
+// learn detects an endpoint that has its own rate limit bucket. When the limit
+// reported for a method and path repeatedly differs from the limit currently
+// known for its class, in the same one minute window, the endpoint can't be
+// sharing the class's bucket. From then on the method and path template is
+// accounted for separately. Returns true if a new bucket was learned.
+func (m *APIMutex) learn(key string, status *APIStatus, method, endPoint string, limit int, reset int64) bool {
+	if strings.HasPrefix(key, learnedPrefix) || status.override > 0 {
+		return false
+	}
+	template := method + " " + pathTemplate(endPoint)
+	if status.limit == 0 || reset <= (status.reset-60) {
+		return false
+	}
+	if status.limit == limit {
+		delete(m.candidates, template)
+		return false
 	}
+	candidate, ok := m.candidates[template]
+	if !ok || candidate.limit != limit {
+		candidate = &learnCandidate{limit: limit}
+		m.candidates[template] = candidate
+	}
+	candidate.count++
+	if candidate.count < learnThreshold {
+		return false
+	}
+	delete(m.candidates, template)
+	class := learnedPrefix + template
+	m.learned[template] = class
+	m.status[class] = &APIStatus{class: class}
+	return true
+}
+
+// Capacity returns the percentage of the rate limits the api mutex allows to be
+// used.
+func (m *APIMutex) Capacity() int {
+	return m.capacity
 }
 
-// Status return the APIStatus for the given class of endpoint.
+// Class returns the endpoint class the rate limits of the given API endpoint
+// are accounted for in.
+func (m *APIMutex) Class(method, endPoint string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.normalizeKey(method, endPoint)
+}
+
+// Status return a copy of the APIStatus for the given class of endpoint.
 func (m *APIMutex) Status(method, endPoint string) *APIStatus {
-	return m.get(method, endPoint)
+	status := m.get(method, endPoint)
+	return &status
 }
 
+const learnedPrefix = "learned "
+
 var (
+	reOktaID  = regexp.MustCompile(`^[0-9A-Za-z]{20}$`)
+	reDigit   = regexp.MustCompile(`[0-9]`)
 	reAppId   = regexp.MustCompile(`/api/v1/apps/[^/]+[/\w]*$`)
 	reGroupId = regexp.MustCompile("/api/v1/groups/[^/]+$")
 	reUserId  = regexp.MustCompile("/api/v1/users/[^/]+$")
 )
 
 func (m *APIMutex) normalizeKey(method, endPoint string) string {
+	// API rate limits can be overwritten by the org admin, those endpoints are
+	// either configured as overrides or learned from the limits observed in
+	// the API responses.
+	for _, o := range m.overrides {
+		if (o.method == "" || o.method == method) && o.pathRegex.MatchString(endPoint) {
+			return o.class
+		}
+	}
+	if class, ok := m.learned[method+" "+pathTemplate(endPoint)]; ok {
+		return class
+	}
+
 	// Okta internal: see rate-limit-mappings-CLASSIC-DEFAULT.txt file in core
 	// repo.  It corresponds to:
 	// https://developer.okta.com/docs/reference/rl-best-practices/
-	//
-	// TODO: API rate limits can be overwritten by the org admin, we should come
-	//       up with a way to accommodate for that.  Perhaps caching an APIStatus
-	//       struct on the SHA of http method + URI path.
 
 	getPutDelete := (http.MethodGet == method) ||
 		(http.MethodPut == method) ||
@@ -233,7 +405,47 @@ func (s *APIStatus) Class() string {
 	return s.class
 }
 
-func (m *APIMutex) get(method, endPoint string) *APIStatus {
+// Budget returns the limit utilization is calculated against. It is the limit
+// reported by the API, as the remaining value is counted against it, and the
+// admin defined override until the API has reported a limit.
+func (s *APIStatus) Budget() int {
+	if s.limit > 0 {
+		return s.limit
+	}
+	return s.override
+}
+
+// Used returns the number of requests used of the budget in the current
+// window.
+func (s *APIStatus) Used() int {
+	if s.limit > 0 {
+		return s.limit - s.remaining
+	}
+	return 0
+}
+
+// get returns a copy of the status for the given class of endpoint, taken
+// while holding the lock so it isn't changed by a concurrent update.
+func (m *APIMutex) get(method, endPoint string) APIStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if time.Since(m.synced) >= m.syncInterval {
+		m.sync(nil)
+	}
 	key := m.normalizeKey(method, endPoint)
-	return m.status[key]
+	return *m.status[key]
+}
+
+// pathTemplate replaces the IDs and logins in a path with a placeholder so
+// requests to the same endpoint share a template, e.g.
+// /api/v1/apps/0oa1abcdefghijklmnop/users -> /api/v1/apps/{id}/users
+func pathTemplate(endPoint string) string {
+	segments := strings.Split(endPoint, "/")
+	for i, segment := range segments {
+		isID := reOktaID.MatchString(segment) && reDigit.MatchString(segment)
+		if isID || strings.Contains(segment, "@") {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
 }
@@ -210,6 +210,112 @@ func TestNormalizeKey(t *testing.T) {
 	}
 }
 
+func TestOverride(t *testing.T) {
+	amu, err := NewAPIMutex(50)
+	if err != nil {
+		t.Fatalf("api mutex constructor had error %+v", err)
+	}
+	if err := amu.AddOverride("", "(", 100); err == nil {
+		t.Fatalf("api mutex should not accept an invalid path regex")
+	}
+	if err := amu.AddOverride("get", "^/api/v1/apps$", 1000); err != nil {
+		t.Fatalf("api mutex add override had error %+v", err)
+	}
+
+	if key := amu.normalizeKey(http.MethodGet, "/api/v1/apps"); key != "override GET ^/api/v1/apps$" {
+		t.Fatalf("got %q, expected the override class for GET /api/v1/apps", key)
+	}
+	if key := amu.normalizeKey(http.MethodPost, "/api/v1/apps"); key != APPS_KEY {
+		t.Fatalf("got %q, expected %q for POST /api/v1/apps", key, APPS_KEY)
+	}
+
+	// the org's raised limit is honored, 400 of 1000 used is below 50%
+	reset := (time.Now().Unix() + int64(60))
+	amu.Update(http.MethodGet, "/api/v1/apps", 1000, 600, reset)
+	if !amu.HasCapacity(http.MethodGet, "/api/v1/apps") {
+		t.Fatalf("api mutex should have capacity, 50%% threshold, 1000 limit, 600 remaining")
+	}
+	amu.Update(http.MethodGet, "/api/v1/apps", 1000, 400, reset)
+	if amu.HasCapacity(http.MethodGet, "/api/v1/apps") {
+		t.Fatalf("api mutex shouldn't have capacity, 50%% threshold, 1000 limit, 400 remaining")
+	}
+	// utilization is calculated against the limit the remaining value is
+	// counted against, not the override
+	amu.Update(http.MethodGet, "/api/v1/apps", 1000, 300, reset)
+	if status := amu.Status(http.MethodGet, "/api/v1/apps"); status.Budget() != 1000 || status.Used() != 700 {
+		t.Fatalf("override class should have 1000 budget and 700 used, was %+v", status)
+	}
+	// and the default class is accounted for separately
+	if status := amu.Status(http.MethodPost, "/api/v1/apps"); status.Remaining() != 0 || status.Limit() != 0 {
+		t.Fatalf("apps class should not have been updated by the override, was %+v", status)
+	}
+}
+
+func TestLearn(t *testing.T) {
+	amu, err := NewAPIMutex(50)
+	if err != nil {
+		t.Fatalf("api mutex constructor had error %+v", err)
+	}
+	reset := (time.Now().Unix() + int64(60))
+	amu.Update(http.MethodGet, "/api/v1/policies", 100, 90, reset)
+	if key := amu.normalizeKey(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop"); key != OTHER_KEY {
+		t.Fatalf("got %q, expected %q before a different limit was observed", key, OTHER_KEY)
+	}
+
+	// a single different limit isn't enough, the class's limit may have changed
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 500, 12, reset)
+	if key := amu.normalizeKey(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop"); key != OTHER_KEY {
+		t.Fatalf("got %q, expected %q after a single different limit", key, OTHER_KEY)
+	}
+	// nor one interrupted by the class's limit
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 500, 12, reset)
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 100, 89, reset)
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 500, 11, reset)
+	if key := amu.normalizeKey(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop"); key != OTHER_KEY {
+		t.Fatalf("got %q, expected %q after the class's limit was reported again", key, OTHER_KEY)
+	}
+
+	// the same different limit repeatedly in the same window reveals a custom
+	// bucket
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 500, 11, reset)
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 500, 10, reset)
+	expected := "learned GET /api/v1/authorizationServers/{id}"
+	for _, endPoint := range []string{"/api/v1/authorizationServers/aus1abcdefghijklmnop", "/api/v1/authorizationServers/aus2abcdefghijklmnop"} {
+		if key := amu.normalizeKey(http.MethodGet, endPoint); key != expected {
+			t.Fatalf("got %q, expected %q for %q", key, expected, endPoint)
+		}
+	}
+	if status := amu.Status(http.MethodGet, "/api/v1/authorizationServers/aus2abcdefghijklmnop"); status.Limit() != 500 || status.Remaining() != 10 {
+		t.Fatalf("learned class should have 500 limit and 10 remaining, was %+v", status)
+	}
+	// the responses until then were conservatively accounted for in the class
+	if status := amu.Status(http.MethodGet, "/api/v1/policies"); status.Limit() != 100 || status.Remaining() != 11 {
+		t.Fatalf("other class should still have 100 limit and 11 remaining, was %+v", status)
+	}
+	amu.Update(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop", 500, 400, reset+60)
+	amu.Update(http.MethodGet, "/api/v1/policies", 100, 90, reset+60)
+	if !amu.HasCapacity(http.MethodGet, "/api/v1/policies") {
+		t.Fatalf("other class should have capacity, 50%% threshold, 100 limit, 90 remaining")
+	}
+	if status := amu.Status(http.MethodGet, "/api/v1/authorizationServers/aus1abcdefghijklmnop"); status.Limit() != 500 || status.Remaining() != 400 {
+		t.Fatalf("learned class should have 500 limit and 400 remaining, was %+v", status)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/apps": "/api/v1/apps",
+		"/api/v1/apps/0oa1abcdefghijklmnop/users":        "/api/v1/apps/{id}/users",
+		"/api/v1/users/jane.doe@example.com":             "/api/v1/users/{id}",
+		"/api/v1/groups/00g1abcdefghijklmnop/roles/list": "/api/v1/groups/{id}/roles/list",
+	}
+	for endPoint, expected := range tests {
+		if got := pathTemplate(endPoint); got != expected {
+			t.Fatalf("got %q, expected %q for %q", got, expected, endPoint)
+		}
+	}
+}
+
 func minRemaining(remaining []int) int {
 	var result int
 	first := true
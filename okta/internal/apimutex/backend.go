@@ -0,0 +1,163 @@
+package apimutex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Backend shares the rate limit statuses known to an api mutex with the api
+// mutexes of other processes, e.g. several provider instances running against
+// the same org on one machine. Without a backend each process only accounts for
+// its own consumption and together they can overrun the budget.
+type Backend interface {
+	// Sync calls fn with the shared statuses, keyed by endpoint class, while
+	// holding an exclusive lock across processes. Changes fn makes to the map
+	// are stored for the other processes.
+	Sync(fn func(shared map[string]*Snapshot)) error
+}
+
+// Snapshot is the shared form of an APIStatus.
+type Snapshot struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+// SetBackend makes the api mutex share its statuses through the backend. The
+// backend is synced right away so a backend that can't be used is reported
+// upfront, later sync errors are ignored and the api mutex carries on with
+// the statuses known to this process.
+func (m *APIMutex) SetBackend(backend Backend) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.backend = backend
+	return m.syncBackend(nil)
+}
+
+// sync merges the shared statuses into the statuses known to this process,
+// calls apply and stores the result for the other processes. apply is called
+// even if the backend can't be synced. Must be called while holding the api
+// mutex lock.
+func (m *APIMutex) sync(apply func()) {
+	if apply == nil {
+		apply = func() {}
+	}
+	applied := false
+	_ = m.syncBackend(func() {
+		applied = true
+		apply()
+	})
+	if !applied {
+		apply()
+	}
+}
+
+func (m *APIMutex) syncBackend(apply func()) error {
+	if m.backend == nil {
+		if apply != nil {
+			apply()
+		}
+		return nil
+	}
+	m.synced = time.Now()
+	return m.backend.Sync(func(shared map[string]*Snapshot) {
+		for class, snapshot := range shared {
+			status, ok := m.status[class]
+			if !ok {
+				if !strings.HasPrefix(class, learnedPrefix) {
+					// an override or class this process doesn't know about
+					continue
+				}
+				// another process learned that this endpoint has its own bucket
+				m.learned[strings.TrimPrefix(class, learnedPrefix)] = class
+				status = &APIStatus{class: class}
+				m.status[class] = status
+			}
+			status.merge(snapshot.Limit, snapshot.Remaining, snapshot.Reset)
+		}
+		if apply != nil {
+			apply()
+		}
+		for class, status := range m.status {
+			if status.reset == 0 {
+				continue
+			}
+			shared[class] = &Snapshot{
+				Limit:     status.limit,
+				Remaining: status.remaining,
+				Reset:     status.reset,
+			}
+		}
+	})
+}
+
+// FileBackend shares the statuses through a JSON file on the local file
+// system. Access to the file is serialized with an exclusive file lock, so
+// every process using the same path shares the same statuses.
+type FileBackend struct {
+	path string
+}
+
+// NewFileBackend returns a file backend storing the statuses at path. The file
+// is created if it doesn't exist.
+func NewFileBackend(path string) (*FileBackend, error) {
+	if path == "" {
+		return nil, fmt.Errorf("expecting a path for the rate limit file")
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rate limit file: %v", err)
+	}
+	_ = f.Close()
+	return &FileBackend{path: path}, nil
+}
+
+// Sync calls fn with the statuses stored in the file while holding an
+// exclusive lock on it, and writes the statuses back afterwards. Statuses of
+// windows that have ended are dropped.
+func (b *FileBackend) Sync(fn func(shared map[string]*Snapshot)) error {
+	f, err := os.OpenFile(b.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open rate limit file: %v", err)
+	}
+	defer f.Close()
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("failed to lock rate limit file: %v", err)
+	}
+	defer func() {
+		_ = unlockFile(f)
+	}()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("failed to read rate limit file: %v", err)
+	}
+	shared := make(map[string]*Snapshot)
+	if len(data) > 0 {
+		// a corrupted file only costs the statuses it held, start over
+		_ = json.Unmarshal(data, &shared)
+	}
+	fn(shared)
+
+	now := time.Now().Unix()
+	for class, snapshot := range shared {
+		if snapshot == nil || snapshot.Reset < now {
+			delete(shared, class)
+		}
+	}
+	data, err = json.Marshal(shared)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limits: %v", err)
+	}
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("failed to write rate limit file: %v", err)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("failed to write rate limit file: %v", err)
+	}
+	return nil
+}
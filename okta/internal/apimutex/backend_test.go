@@ -0,0 +1,140 @@
+package apimutex
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newSharedAPIMutex(t *testing.T, path string) *APIMutex {
+	amu, err := NewAPIMutex(50)
+	if err != nil {
+		t.Fatalf("api mutex constructor had error %+v", err)
+	}
+	backend, err := NewFileBackend(path)
+	if err != nil {
+		t.Fatalf("file backend constructor had error %+v", err)
+	}
+	if err := amu.SetBackend(backend); err != nil {
+		t.Fatalf("setting the backend had error %+v", err)
+	}
+	// see the other api mutexes' changes right away
+	amu.syncInterval = 0
+	return amu
+}
+
+func TestFileBackendSharesStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+	first := newSharedAPIMutex(t, path)
+	second := newSharedAPIMutex(t, path)
+
+	endPoint := "/api/v1/groups"
+	reset := time.Now().Unix() + 60
+	first.Update(http.MethodGet, endPoint, 100, 40, reset)
+
+	status := second.Status(http.MethodGet, endPoint)
+	if status.Limit() != 100 || status.Remaining() != 40 || status.Reset() != reset {
+		t.Fatalf("expected the status updated by the other api mutex, got limit %d, remaining %d, reset %d", status.Limit(), status.Remaining(), status.Reset())
+	}
+	if second.HasCapacity(http.MethodGet, endPoint) {
+		t.Fatalf("api mutex shouldn't have capacity, the other api mutex used 60 of 100 at 50%% capacity")
+	}
+
+	// requests in flight are seen by the other api mutex
+	second.Consume(http.MethodGet, endPoint)
+	second.Consume(http.MethodGet, endPoint)
+	if remaining := first.Status(http.MethodGet, endPoint).Remaining(); remaining != 38 {
+		t.Fatalf("expected 38 remaining after the other api mutex consumed 2, got %d", remaining)
+	}
+
+	// a later response with a higher remaining value doesn't give back the
+	// consumed budget
+	first.Update(http.MethodGet, endPoint, 100, 39, reset)
+	if remaining := second.Status(http.MethodGet, endPoint).Remaining(); remaining != 38 {
+		t.Fatalf("expected 38 remaining, got %d", remaining)
+	}
+}
+
+func TestFileBackendSharesLearnedBuckets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+	first := newSharedAPIMutex(t, path)
+	second := newSharedAPIMutex(t, path)
+
+	reset := time.Now().Unix() + 60
+	first.Update(http.MethodGet, "/api/v1/domains", 1000, 999, reset)
+	for remaining := 49; remaining > 49-learnThreshold; remaining-- {
+		first.Update(http.MethodGet, "/api/v1/authorizationServers", 50, remaining, reset)
+	}
+
+	status := second.Status(http.MethodGet, "/api/v1/authorizationServers")
+	if status.Class() != learnedPrefix+"GET /api/v1/authorizationServers" || status.Remaining() != 50-learnThreshold {
+		t.Fatalf("expected the bucket learned by the other api mutex, got class %q with %d remaining", status.Class(), status.Remaining())
+	}
+}
+
+func TestFileBackendConcurrentConsume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+	mutexes := []*APIMutex{newSharedAPIMutex(t, path), newSharedAPIMutex(t, path), newSharedAPIMutex(t, path)}
+
+	endPoint := "/api/v1/users"
+	mutexes[0].Update(http.MethodPost, endPoint, 600, 600, time.Now().Unix()+60)
+
+	var wg sync.WaitGroup
+	for _, amu := range mutexes {
+		wg.Add(1)
+		go func(amu *APIMutex) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				amu.Consume(http.MethodPost, endPoint)
+			}
+		}(amu)
+	}
+	wg.Wait()
+
+	for i, amu := range mutexes {
+		if remaining := amu.Status(http.MethodPost, endPoint).Remaining(); remaining != 450 {
+			t.Fatalf("api mutex %d expected 450 remaining after 150 requests, got %d", i, remaining)
+		}
+	}
+}
+
+func TestFileBackendDropsEndedWindows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+	amu := newSharedAPIMutex(t, path)
+	amu.Update(http.MethodGet, "/api/v1/apps", 100, 10, time.Now().Unix()-120)
+	amu.Update(http.MethodGet, "/api/v1/groups", 100, 10, time.Now().Unix()+60)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the rate limit file had error %+v", err)
+	}
+	other := newSharedAPIMutex(t, path)
+	if status := other.Status(http.MethodGet, "/api/v1/apps"); status.Reset() != 0 {
+		t.Fatalf("expected the ended window to be dropped from %s", data)
+	}
+	if status := other.Status(http.MethodGet, "/api/v1/groups"); status.Remaining() != 10 {
+		t.Fatalf("expected the current window to be kept in %s", data)
+	}
+}
+
+func TestFileBackendThrottlesReadSyncs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate-limits.json")
+	first := newSharedAPIMutex(t, path)
+	second := newSharedAPIMutex(t, path)
+	second.syncInterval = time.Hour
+
+	endPoint := "/api/v1/groups"
+	first.Update(http.MethodGet, endPoint, 100, 40, time.Now().Unix()+60)
+	if status := second.Status(http.MethodGet, endPoint); status.Remaining() != 0 {
+		t.Fatalf("reading a status shouldn't have synced the backend, got %d remaining", status.Remaining())
+	}
+
+	// consuming always syncs the backend
+	second.Consume(http.MethodGet, endPoint)
+	if status := second.Status(http.MethodGet, endPoint); status.Remaining() != 39 {
+		t.Fatalf("expected 39 remaining after consuming, got %d", status.Remaining())
+	}
+}
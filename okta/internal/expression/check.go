@@ -0,0 +1,373 @@
+package expression
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Error is a problem of an expression at the offset Pos, a warning doesn't
+// necessarily make the expression invalid.
+type Error struct {
+	Pos     int
+	Msg     string
+	Warning bool
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+// Env is what an expression can refer to.
+type Env struct {
+	// Variables are the variables the expression can use, e.g. user and
+	// appuser.
+	Variables []string
+	// Attributes are the known attributes of the variables. An attribute
+	// missing from the list is reported as a warning when its name is close
+	// to a known one, e.g. user.fristName, and as an error when the variable
+	// is in Closed.
+	Attributes map[string][]string
+	Closed     map[string]bool
+}
+
+// function is the number of arguments a function takes, max is -1 when it
+// takes any number of arguments from min.
+type function struct {
+	min, max int
+}
+
+// functions are the functions of the Okta Expression Language by qualified
+// name, see https://developer.okta.com/docs/reference/okta-expression-language/
+var functions = map[string]function{
+	"String.append":          {2, 2},
+	"String.join":            {2, -1},
+	"String.len":             {1, 1},
+	"String.removeSpaces":    {1, 1},
+	"String.replace":         {3, 3},
+	"String.replaceFirst":    {3, 3},
+	"String.stringContains":  {2, 2},
+	"String.stringSwitch":    {4, -1},
+	"String.startsWith":      {2, 2},
+	"String.substring":       {3, 3},
+	"String.substringAfter":  {2, 2},
+	"String.substringBefore": {2, 2},
+	"String.toLowerCase":     {1, 1},
+	"String.toUpperCase":     {1, 1},
+
+	"Arrays.add":         {2, 2},
+	"Arrays.remove":      {2, 2},
+	"Arrays.clear":       {1, 1},
+	"Arrays.get":         {2, 2},
+	"Arrays.flatten":     {1, -1},
+	"Arrays.contains":    {2, 2},
+	"Arrays.size":        {1, 1},
+	"Arrays.isEmpty":     {1, 1},
+	"Arrays.toCsvString": {1, 1},
+
+	"Convert.toInt": {1, 1},
+	"Convert.toNum": {1, 1},
+
+	"Iso3166Convert.toAlpha2":  {1, 1},
+	"Iso3166Convert.toAlpha3":  {1, 1},
+	"Iso3166Convert.toNumeric": {1, 1},
+	"Iso3166Convert.toName":    {1, 1},
+
+	"Groups.contains":   {3, 3},
+	"Groups.startsWith": {3, 3},
+	"Groups.endsWith":   {3, 3},
+
+	"Time.now":                  {0, 2},
+	"Time.fromWindowsToIso8601": {1, 1},
+	"Time.fromIso8601ToWindows": {1, 1},
+	"Time.fromUnixToIso8601":    {1, 1},
+	"Time.fromIso8601ToUnix":    {1, 1},
+	"Time.fromStringToIso8601":  {2, 2},
+	"Time.fromIso8601ToString":  {2, 2},
+
+	"isMemberOfGroup":               {1, 1},
+	"isMemberOfAnyGroup":            {1, -1},
+	"isMemberOfGroupName":           {1, 1},
+	"isMemberOfGroupNameStartsWith": {1, 1},
+	"isMemberOfGroupNameContains":   {1, 1},
+	"isMemberOfGroupNameRegex":      {1, 1},
+	"getManagerUser":                {1, 1},
+	"getManagerAppUser":             {2, 2},
+	"getAssistantUser":              {1, 1},
+	"getAssistantAppUser":           {2, 2},
+	"hasDirectoryUser":              {0, 0},
+	"findDirectoryUser":             {0, 0},
+	"hasWorkdayUser":                {0, 0},
+	"findWorkdayUser":               {0, 0},
+
+	"user.isMemberOf":          {1, 1},
+	"user.getGroups":           {1, 2},
+	"user.getInternalProperty": {1, 1},
+	"user.getLinkedObject":     {1, 1},
+}
+
+// namespaces are the qualifiers of the functions, e.g. String.
+var namespaces = func() map[string]bool {
+	res := make(map[string]bool)
+	for name := range functions {
+		if i := strings.Index(name, "."); i > 0 && name[:i] != "user" {
+			res[name[:i]] = true
+		}
+	}
+	return res
+}()
+
+// Check parses the expression and checks the variables, attributes and
+// function calls it has against env. It returns the syntax error, or every
+// problem it found.
+func Check(src string, env *Env) []*Error {
+	node, err := Parse(src)
+	if err != nil {
+		return []*Error{err.(*Error)}
+	}
+	c := &checker{env: env}
+	c.check(node)
+	sort.SliceStable(c.errs, func(i, j int) bool {
+		return c.errs[i].Pos < c.errs[j].Pos
+	})
+	return c.errs
+}
+
+type checker struct {
+	env  *Env
+	errs []*Error
+}
+
+func (c *checker) errorf(pos int, format string, args ...interface{}) {
+	c.errs = append(c.errs, &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) warnf(pos int, format string, args ...interface{}) {
+	c.errs = append(c.errs, &Error{Pos: pos, Msg: fmt.Sprintf(format, args...), Warning: true})
+}
+
+func (c *checker) check(node Node) {
+	switch n := node.(type) {
+	case *Ident:
+		c.ident(n)
+	case *Member:
+		c.member(n)
+	case *Call:
+		c.call(n)
+	case *Index:
+		c.check(n.X)
+		c.check(n.Index)
+	case *Unary:
+		c.check(n.X)
+	case *Binary:
+		c.check(n.X)
+		c.check(n.Y)
+	case *Conditional:
+		c.check(n.Cond)
+		if n.Then != nil {
+			c.check(n.Then)
+		}
+		c.check(n.Else)
+	case *List:
+		for _, e := range n.Elems {
+			c.check(e)
+		}
+	case *Map:
+		for i := range n.Keys {
+			c.check(n.Keys[i])
+			c.check(n.Values[i])
+		}
+	}
+}
+
+func (c *checker) ident(n *Ident) {
+	switch {
+	case c.isVariable(n.Name):
+	case namespaces[n.Name]:
+		c.errorf(n.pos, "%s is a namespace of functions, not a value", n.Name)
+	case isFunction(n.Name):
+		c.errorf(n.pos, "%s is a function, call it as %s(...)", n.Name, n.Name)
+	default:
+		// a warning rather than an error, the variables of an expression
+		// depend on where it is evaluated and aren't all known
+		c.warnf(n.pos, "unknown variable %q, expected one of %s", n.Name, strings.Join(c.env.Variables, ", "))
+	}
+}
+
+func (c *checker) member(n *Member) {
+	x, ok := n.X.(*Ident)
+	if !ok {
+		c.check(n.X)
+		return
+	}
+	name := x.Name + "." + n.Name
+	switch {
+	case namespaces[x.Name]:
+		if isFunction(name) {
+			c.errorf(x.pos, "%s is a function, call it as %s(...)", name, name)
+		} else {
+			c.unknownFunction(x.pos, name)
+		}
+	case c.isVariable(x.Name):
+		c.attribute(x.Name, n.Name, n.NamePos)
+	default:
+		c.ident(x)
+	}
+}
+
+// attribute checks the attribute of the variable against the known ones.
+func (c *checker) attribute(variable, name string, pos int) {
+	known, ok := c.env.Attributes[variable]
+	if !ok || strings.HasPrefix(name, "$") {
+		return
+	}
+	for _, k := range known {
+		if k == name {
+			return
+		}
+	}
+	suggestion := closest(name, known)
+	switch {
+	case c.env.Closed[variable] && suggestion != "":
+		c.errorf(pos, "unknown attribute %s.%s, did you mean %s.%s?", variable, name, variable, suggestion)
+	case c.env.Closed[variable]:
+		c.errorf(pos, "unknown attribute %s.%s", variable, name)
+	case suggestion != "":
+		c.warnf(pos, "%s.%s isn't a base attribute, did you mean %s.%s? Ignore this if it is a custom attribute",
+			variable, name, variable, suggestion)
+	}
+}
+
+func (c *checker) call(n *Call) {
+	for _, arg := range n.Args {
+		c.check(arg)
+	}
+	var name string
+	switch fun := n.Fun.(type) {
+	case *Ident:
+		name = fun.Name
+		if !isFunction(name) {
+			if c.isVariable(name) || namespaces[name] {
+				c.errorf(fun.pos, "%s isn't a function", name)
+			} else {
+				c.unknownFunction(fun.pos, name)
+			}
+			return
+		}
+	case *Member:
+		x, ok := fun.X.(*Ident)
+		if !ok {
+			// a method of a value, e.g. user.login.substring(0, 3)
+			c.check(fun.X)
+			return
+		}
+		name = x.Name + "." + fun.Name
+		switch {
+		case namespaces[x.Name] || x.Name == "user" && c.isVariable("user"):
+			if !isFunction(name) {
+				c.unknownFunction(x.pos, name)
+				return
+			}
+		case c.isVariable(x.Name):
+			c.attribute(x.Name, fun.Name, fun.NamePos)
+			return
+		default:
+			c.ident(x)
+			return
+		}
+	default:
+		c.check(n.Fun)
+		return
+	}
+	f := functions[name]
+	switch args := len(n.Args); {
+	case f.max == -1 && args < f.min:
+		c.errorf(n.Pos(), "%s takes at least %s, got %d", name, arguments(f.min), args)
+	case f.max != -1 && (args < f.min || args > f.max) && f.min == f.max:
+		c.errorf(n.Pos(), "%s takes %s, got %d", name, arguments(f.min), args)
+	case f.max != -1 && (args < f.min || args > f.max):
+		c.errorf(n.Pos(), "%s takes %d to %s, got %d", name, f.min, arguments(f.max), args)
+	}
+}
+
+func (c *checker) unknownFunction(pos int, name string) {
+	var candidates []string
+	for f := range functions {
+		candidates = append(candidates, f)
+	}
+	if suggestion := closest(name, candidates); suggestion != "" {
+		c.errorf(pos, "unknown function %s, did you mean %s?", name, suggestion)
+		return
+	}
+	c.errorf(pos, "unknown function %s", name)
+}
+
+func (c *checker) isVariable(name string) bool {
+	for _, v := range c.env.Variables {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isFunction(name string) bool {
+	_, ok := functions[name]
+	return ok
+}
+
+func arguments(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", n)
+}
+
+// closest returns the candidate name is most likely a typo of, it is empty
+// when none of them is close enough.
+func closest(name string, candidates []string) string {
+	best, bestDistance := "", 3
+	if len(name) <= 3 {
+		bestDistance = 1
+	}
+	for _, candidate := range candidates {
+		if strings.EqualFold(name, candidate) {
+			return candidate
+		}
+		if d := distance(strings.ToLower(name), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// distance is the Levenshtein distance of a and b.
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minOf(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minOf(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,80 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckValid(t *testing.T) {
+	tests := []string{
+		`user.department == "Engineering"`,
+		`user.department eq 'Engineering' AND user.title != "Intern"`,
+		`String.substringAfter(user.email, "@") == "example.com"`,
+		`String.startsWith(user.firstName, String.toLowerCase("bOb"))`,
+		`isMemberOfGroupName("Everyone") || isMemberOfAnyGroup("00g1", "00g2")`,
+		`Groups.contains(app.type, "Sales", 10)`,
+		`user.nickName ?: user.firstName`,
+		`user.countryCode == null ? "US" : Iso3166Convert.toAlpha2(user.countryCode)`,
+		`String.toUpperCase(String.substring(appuser.lastName, 0, 1)) + appuser.firstName`,
+		`user.isMemberOf({'group.profile.name': 'Everyone', 'operator': 'EXACT'})`,
+		`Arrays.contains({"a", "b"}, user.costCenter)`,
+		`!(user.employeeNumber matches '[0-9]+') and not hasDirectoryUser()`,
+		`user.customAttribute == 'it''s'`,
+		`source.login`,
+		`user.getLinkedObject("manager").email`,
+		`Time.now()`,
+		`user.customArray[0] + -1 * 2.5 % 3`,
+	}
+	for _, expr := range tests {
+		if errs := Check(expr, DefaultEnv()); len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got %v", expr, errs)
+		}
+	}
+}
+
+func TestCheckInvalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		pos     int
+		message string
+		warning bool
+	}{
+		{expr: `user.department ==`, pos: 18, message: "expected a value, got end of expression"},
+		{expr: `user.department == "Engineering`, pos: 19, message: "unterminated string"},
+		{expr: `String.substringAfter(user.email "@")`, pos: 33, message: `expected "," or ")", got string "@"`},
+		{expr: `user.login # 1`, pos: 11, message: `unexpected character '#'`},
+		{expr: `(user.login`, pos: 11, message: `expected ")", got end of expression`},
+		{expr: ``, pos: 0, message: "empty expression"},
+		{expr: `String.substringAftr(user.email, "@")`, pos: 0, message: "unknown function String.substringAftr, did you mean String.substringAfter?"},
+		{expr: `String.substringAfter(user.email)`, pos: 0, message: "String.substringAfter takes 2 arguments, got 1"},
+		{expr: `Groups.contains(app.type, "Sales")`, pos: 0, message: "Groups.contains takes 3 arguments, got 2"},
+		{expr: `String.join(",")`, pos: 0, message: "String.join takes at least 2 arguments, got 1"},
+		{expr: `isMemberOfGroupNam("Everyone")`, pos: 0, message: "unknown function isMemberOfGroupNam, did you mean isMemberOfGroupName?"},
+		{expr: `user.getGroup({'group.type': {'OKTA_GROUP'}})`, pos: 0, message: "unknown function user.getGroup, did you mean user.getGroups?"},
+		{expr: `usr.email == "a"`, pos: 0, message: `unknown variable "usr"`, warning: true},
+		{expr: `cool`, pos: 0, message: `unknown variable "cool"`, warning: true},
+		{expr: `String.len`, pos: 0, message: "String.len is a function, call it as String.len(...)"},
+		{expr: `user.fristName`, pos: 5, message: "user.fristName isn't a base attribute, did you mean user.firstName?", warning: true},
+		{expr: `appuser.username`, pos: 8, message: "appuser.username isn't a base attribute, did you mean appuser.userName?", warning: true},
+	}
+	for _, test := range tests {
+		errs := Check(test.expr, DefaultEnv())
+		if len(errs) != 1 {
+			t.Errorf("expected one problem with %q, got %v", test.expr, errs)
+			continue
+		}
+		if errs[0].Pos != test.pos || !strings.HasPrefix(errs[0].Msg, test.message) || errs[0].Warning != test.warning {
+			t.Errorf("unexpected problem with %q, expected %q at %d, got %+v", test.expr, test.message, test.pos, errs[0])
+		}
+	}
+}
+
+func TestCheckClosedAttributes(t *testing.T) {
+	env := DefaultEnv()
+	env.Closed = map[string]bool{"user": true}
+	errs := Check(`user.favoriteColor == "a" && user.fristName == "b"`, env)
+	if len(errs) != 2 || errs[0].Msg != "unknown attribute user.favoriteColor" ||
+		errs[1].Msg != "unknown attribute user.fristName, did you mean user.firstName?" || errs[1].Warning {
+		t.Fatalf("expected unknown attributes of a closed variable to be errors, got %v", errs)
+	}
+}
@@ -0,0 +1,33 @@
+package expression
+
+// UserAttributes are the attributes of the base Okta user profile.
+var UserAttributes = []string{
+	"login", "email", "secondEmail", "firstName", "lastName", "middleName", "honorificPrefix",
+	"honorificSuffix", "title", "displayName", "nickName", "profileUrl", "primaryPhone", "mobilePhone",
+	"streetAddress", "city", "state", "zipCode", "postalAddress", "countryCode", "preferredLanguage",
+	"locale", "timezone", "userType", "employeeNumber", "costCenter", "organization", "division",
+	"department", "managerId", "manager",
+}
+
+// AppUserAttributes are the attributes most app user profiles have, apps
+// have attributes of their own so they are never a closed list.
+var AppUserAttributes = []string{
+	"userName", "email", "firstName", "lastName", "middleName", "honorificPrefix", "honorificSuffix",
+	"title", "displayName", "nickName", "profileUrl", "primaryPhone", "mobilePhone", "streetAddress",
+	"locality", "region", "postalCode", "countryCode", "preferredLanguage", "locale", "timezone",
+	"userType", "employeeNumber", "costCenter", "organization", "division", "department", "managerId",
+	"manager",
+}
+
+// DefaultEnv returns the variables expressions can use across Okta, with the
+// base user and app user attributes as the known attributes. Custom
+// attributes are allowed, only likely typos of the base ones are reported.
+func DefaultEnv() *Env {
+	return &Env{
+		Variables: []string{"user", "appuser", "app", "org", "idpuser", "access", "session", "source", "group"},
+		Attributes: map[string][]string{
+			"user":    UserAttributes,
+			"appuser": AppUserAttributes,
+		},
+	}
+}
@@ -0,0 +1,129 @@
+// Package expression parses and checks Okta Expression Language expressions,
+// the SpEL based language of group rules, profile mappings, claims and SAML
+// attribute statements, so mistakes are found at plan time rather than as API
+// errors during apply.
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+)
+
+// token is a lexeme of an expression, pos is the offset of its first byte.
+type token struct {
+	kind tokenKind
+	text string
+	// value is the unquoted value of a string
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return "string " + t.text
+	case tokenNumber:
+		return "number " + t.text
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators are sorted longest first, so the lexer takes "==" over "=".
+var operators = []string{
+	"?:", "?.", "==", "!=", "<=", ">=", "&&", "||",
+	"(", ")", "{", "}", "[", "]", ",", ".", ":", "?", "+", "-", "*", "/", "%", "^", "!", "<", ">",
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isIdentStart(c):
+			start := i
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:i], pos: start})
+		case isDigit(c):
+			start := i
+			for i < len(src) && isDigit(src[i]) {
+				i++
+			}
+			if i+1 < len(src) && src[i] == '.' && isDigit(src[i+1]) {
+				i++
+				for i < len(src) && isDigit(src[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], pos: start})
+		case c == '\'' || c == '"':
+			t, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i += len(t.text)
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, &Error{Pos: i, Msg: fmt.Sprintf("unexpected character %q", src[i])}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// lexString lexes the string starting at src[start], the quote is escaped by
+// doubling it as in SpEL, e.g. "say ""hi""".
+func lexString(src string, start int) (token, error) {
+	quote := src[start]
+	var value strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		if src[i] != quote {
+			value.WriteByte(src[i])
+			continue
+		}
+		if i+1 < len(src) && src[i+1] == quote {
+			value.WriteByte(quote)
+			i++
+			continue
+		}
+		return token{kind: tokenString, text: src[start : i+1], value: value.String(), pos: start}, nil
+	}
+	return token{}, &Error{Pos: start, Msg: "unterminated string"}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,381 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a node of the syntax tree of an expression.
+type Node interface {
+	// Pos is the offset of the first byte of the node in the expression.
+	Pos() int
+}
+
+type (
+	// Literal is a string, number, boolean or null literal.
+	Literal struct {
+		pos   int
+		Value string
+	}
+
+	// Ident is a variable such as user, or the name of a function or
+	// namespace such as String.
+	Ident struct {
+		pos  int
+		Name string
+	}
+
+	// Member is the access to a property of X, e.g. user.firstName.
+	Member struct {
+		X    Node
+		Name string
+		// NamePos is the offset of Name
+		NamePos int
+	}
+
+	// Call is a call of a function or method, e.g. String.len(user.login).
+	Call struct {
+		Fun  Node
+		Args []Node
+	}
+
+	// Index is the access to an element of X, e.g. user.emails[0].
+	Index struct {
+		X     Node
+		Index Node
+	}
+
+	// Unary is an operation on one operand, e.g. !isMemberOfGroup("id").
+	Unary struct {
+		pos int
+		Op  string
+		X   Node
+	}
+
+	// Binary is an operation on two operands, e.g. user.age > 18.
+	Binary struct {
+		Op   string
+		X, Y Node
+	}
+
+	// Conditional is a ternary or, without Then, an elvis operation, e.g.
+	// user.nickName ?: user.firstName.
+	Conditional struct {
+		Cond, Then, Else Node
+	}
+
+	// List is an inline list, e.g. {"a", "b"}.
+	List struct {
+		pos   int
+		Elems []Node
+	}
+
+	// Map is an inline map, e.g. {'group.profile.name': 'Everyone'}.
+	Map struct {
+		pos    int
+		Keys   []Node
+		Values []Node
+	}
+)
+
+func (n *Literal) Pos() int     { return n.pos }
+func (n *Ident) Pos() int       { return n.pos }
+func (n *Member) Pos() int      { return n.X.Pos() }
+func (n *Call) Pos() int        { return n.Fun.Pos() }
+func (n *Index) Pos() int       { return n.X.Pos() }
+func (n *Unary) Pos() int       { return n.pos }
+func (n *Binary) Pos() int      { return n.X.Pos() }
+func (n *Conditional) Pos() int { return n.Cond.Pos() }
+func (n *List) Pos() int        { return n.pos }
+func (n *Map) Pos() int         { return n.pos }
+
+// keyword operators, they are case insensitive
+var (
+	orOperators         = []string{"||", "or"}
+	andOperators        = []string{"&&", "and"}
+	relationalOperators = []string{"==", "!=", "<", ">", "<=", ">=", "eq", "ne", "lt", "gt", "le", "ge", "matches", "instanceof"}
+	notOperators        = []string{"!", "not"}
+	literalKeywords     = []string{"true", "false", "null"}
+)
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+// Parse parses an Okta Expression Language expression, it returns an *Error
+// with the position of the first syntax error.
+func Parse(src string) (Node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, &Error{Pos: 0, Msg: "empty expression"}
+	}
+	p := &parser{tokens: tokens}
+	node, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t, "an operator or the end of the expression")
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+// accept advances past the next token if it is one of the operators or
+// keywords.
+func (p *parser) accept(ops ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator && t.kind != tokenIdent {
+		return t, false
+	}
+	for _, op := range ops {
+		if t.kind == tokenOperator && t.text == op || t.kind == tokenIdent && isKeyword(op) && strings.EqualFold(t.text, op) {
+			p.advance()
+			return t, true
+		}
+	}
+	return t, false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		return p.unexpected(p.peek(), fmt.Sprintf("%q", op))
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return &Error{Pos: t.pos, Msg: fmt.Sprintf("expected %s, got %s", expected, t)}
+}
+
+func isKeyword(op string) bool {
+	return isIdentStart(op[0])
+}
+
+// expression parses the ternary and elvis operations, they have the lowest
+// precedence.
+func (p *parser) expression() (Node, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?:"); ok {
+		alt, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return &Conditional{Cond: cond, Else: alt}, nil
+	}
+	if _, ok := p.accept("?"); ok {
+		then, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		alt, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return &Conditional{Cond: cond, Then: then, Else: alt}, nil
+	}
+	return cond, nil
+}
+
+// binaryLevels are the binary operators from the lowest precedence to the
+// highest.
+var binaryLevels = [][]string{
+	orOperators,
+	andOperators,
+	relationalOperators,
+	{"+", "-"},
+	{"*", "/", "%"},
+	{"^"},
+}
+
+func (p *parser) binary(level int) (Node, error) {
+	if level == len(binaryLevels) {
+		return p.unary()
+	}
+	x, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept(binaryLevels[level]...)
+		if !ok {
+			return x, nil
+		}
+		y, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: strings.ToLower(t.text), X: x, Y: y}
+	}
+}
+
+func (p *parser) unary() (Node, error) {
+	ops := append([]string{"-", "+"}, notOperators...)
+	if t, ok := p.accept(ops...); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{pos: t.pos, Op: strings.ToLower(t.text), X: x}, nil
+	}
+	return p.postfix()
+}
+
+func (p *parser) postfix() (Node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch t := p.peek(); {
+		case t.kind == tokenOperator && (t.text == "." || t.text == "?."):
+			p.advance()
+			name := p.advance()
+			if name.kind != tokenIdent {
+				return nil, p.unexpected(name, "a property or method name")
+			}
+			x = &Member{X: x, Name: name.text, NamePos: name.pos}
+		case t.kind == tokenOperator && t.text == "(":
+			p.advance()
+			args, err := p.list(")")
+			if err != nil {
+				return nil, err
+			}
+			x = &Call{Fun: x, Args: args}
+		case t.kind == tokenOperator && t.text == "[":
+			p.advance()
+			index, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &Index{X: x, Index: index}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) primary() (Node, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenString:
+		return &Literal{pos: t.pos, Value: t.value}, nil
+	case tokenNumber:
+		return &Literal{pos: t.pos, Value: t.text}, nil
+	case tokenIdent:
+		for _, k := range literalKeywords {
+			if strings.EqualFold(t.text, k) {
+				return &Literal{pos: t.pos, Value: strings.ToLower(t.text)}, nil
+			}
+		}
+		return &Ident{pos: t.pos, Name: t.text}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			x, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+		case "{":
+			return p.inline(t.pos)
+		}
+	}
+	return nil, p.unexpected(t, "a value")
+}
+
+// list parses the comma separated expressions up to the closing operator.
+func (p *parser) list(closing string) ([]Node, error) {
+	var nodes []Node
+	if _, ok := p.accept(closing); ok {
+		return nodes, nil
+	}
+	for {
+		x, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, x)
+		if _, ok := p.accept(closing); ok {
+			return nodes, nil
+		}
+		if _, ok := p.accept(","); !ok {
+			return nil, p.unexpected(p.peek(), fmt.Sprintf("\",\" or %q", closing))
+		}
+	}
+}
+
+// inline parses an inline list or map, their opening brace at pos is
+// consumed.
+func (p *parser) inline(pos int) (Node, error) {
+	if _, ok := p.accept("}"); ok {
+		return &List{pos: pos}, nil
+	}
+	if _, ok := p.accept(":"); ok {
+		return &Map{pos: pos}, p.expect("}")
+	}
+	first, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept(":"); !ok {
+		elems := []Node{first}
+		if _, ok := p.accept("}"); ok {
+			return &List{pos: pos, Elems: elems}, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		rest, err := p.list("}")
+		if err != nil {
+			return nil, err
+		}
+		return &List{pos: pos, Elems: append(elems, rest...)}, nil
+	}
+	m := &Map{pos: pos}
+	key := first
+	for {
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		m.Keys = append(m.Keys, key)
+		m.Values = append(m.Values, value)
+		if _, ok := p.accept("}"); ok {
+			return m, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		if key, err = p.expression(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const skipCacheKey contextKey = "skip-cache"
+
+// SkipCache returns a context whose GET requests are made to the API rather
+// than served from the cache, e.g. to poll the API until a change shows up.
+// Their responses still refresh the cache.
+func SkipCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey, true)
+}
+
+// CacheTransport keeps the successful responses to GET requests in memory
+// for the life of the provider process, and serves repeated GET requests of
+// the same URL from memory. Every page of a listing has its own URL, so
+// pagination works as it does without the cache.
+//
+// A request that could change the org invalidates the cached responses it
+// could affect: all the responses of its resource collection, e.g. of
+// /api/v1/groups for PUT /api/v1/groups/{groupId}/users/{userId}, and all the
+// responses of paths having one of its object IDs, e.g.
+// /api/v1/users/{userId}/groups. Deleting an object also invalidates the lists
+// of its kind of objects under the other collections, e.g.
+// /api/v1/groups/{groupId}/users for DELETE /api/v1/users/{userId}.
+type CacheTransport struct {
+	base   http.RoundTripper
+	logger hclog.Logger
+
+	lock    sync.Mutex
+	entries map[string]*cacheEntry
+	// generation is incremented on every invalidation, a response fetched
+	// across an invalidation might be stale and isn't kept
+	generation    uint64
+	hits          int
+	misses        int
+	invalidations int
+}
+
+type cacheEntry struct {
+	collection string
+	segments   []string
+	status     string
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// NewCacheTransport returns a cache transport on top of the base transport.
+func NewCacheTransport(base http.RoundTripper, logger hclog.Logger) *CacheTransport {
+	return &CacheTransport{
+		base:    base,
+		logger:  logger,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// RoundTrip serves GET requests from the cache when it can, and invalidates
+// the cache on all the other requests but HEAD.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return t.get(req)
+	case http.MethodHead:
+		return t.base.RoundTrip(req)
+	}
+	if req.Method == http.MethodPost && req.URL.Path == "/oauth2/v1/token" {
+		// minting an access token doesn't change the org
+		return t.base.RoundTrip(req)
+	}
+	resp, err := t.base.RoundTrip(req)
+	// invalidates even when the request failed, the change might have been
+	// made anyway
+	t.invalidate(req.Method, req.URL.Path)
+	return resp, err
+}
+
+// LogStats logs how many GET requests the cache served.
+func (t *CacheTransport) LogStats() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.logger.Info(fmt.Sprintf("API read cache statistics: %d hits, %d misses, %d invalidations", t.hits, t.misses, t.invalidations))
+}
+
+func (t *CacheTransport) get(req *http.Request) (*http.Response, error) {
+	key := req.URL.String()
+	skip, _ := req.Context().Value(skipCacheKey).(bool)
+
+	t.lock.Lock()
+	if entry, ok := t.entries[key]; ok && !skip {
+		t.hits++
+		t.lock.Unlock()
+		return entry.response(req), nil
+	}
+	t.misses++
+	generation := t.generation
+	t.lock.Unlock()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	collection, segments := splitPath(req.URL.Path)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.generation == generation {
+		t.entries[key] = &cacheEntry{
+			collection: collection,
+			segments:   segments,
+			status:     resp.Status,
+			statusCode: resp.StatusCode,
+			header:     resp.Header.Clone(),
+			body:       body,
+		}
+	}
+	return resp, nil
+}
+
+func (t *CacheTransport) invalidate(method, path string) {
+	collection, segments := splitPath(path)
+	// the object IDs are every other segment after the collection, e.g.
+	// {groupId} and {userId} of /api/v1/groups/{groupId}/users/{userId}
+	var ids []string
+	for i := 3; i < len(segments); i += 2 {
+		ids = append(ids, segments[i])
+	}
+	// deleting an object, e.g. DELETE /api/v1/users/{userId}, also removes it
+	// from the lists of the other objects it belongs to, whose paths don't
+	// have its ID, e.g. /api/v1/groups/{groupId}/users
+	var kind string
+	if method == http.MethodDelete && len(segments) == 4 {
+		kind = segments[2]
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.generation++
+	t.invalidations++
+	for key, entry := range t.entries {
+		if entry.collection == collection || entry.hasSegment(ids) || entry.listsKind(kind) {
+			delete(t.entries, key)
+		}
+	}
+}
+
+func (e *cacheEntry) hasSegment(values []string) bool {
+	for _, segment := range e.segments {
+		for _, value := range values {
+			if segment == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listsKind tells whether the entry is a list of objects of the kind that
+// belongs to another object, e.g. /api/v1/groups/{groupId}/users for users.
+func (e *cacheEntry) listsKind(kind string) bool {
+	if kind == "" || len(e.segments) <= 3 {
+		return false
+	}
+	for _, segment := range e.segments[3:] {
+		if segment == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// splitPath returns the resource collection of the path, its first three
+// segments e.g. /api/v1/groups, and all its segments.
+func splitPath(path string) (string, []string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	n := len(segments)
+	if n > 3 {
+		n = 3
+	}
+	return "/" + strings.Join(segments[:n], "/"), segments
+}
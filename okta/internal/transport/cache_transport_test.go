@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestCacheTransport(t *testing.T) {
+	made := map[string]int{}
+	transport := NewCacheTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		made[req.Method+" "+req.URL.RequestURI()]++
+		status := http.StatusOK
+		if req.URL.Path == "/api/v1/apps/missing" {
+			status = http.StatusNotFound
+		}
+		header := http.Header{}
+		header.Set("Link", `<https://test.okta.com/api/v1/groups?after=2>; rel="next"`)
+		return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(req.URL.RequestURI()))}, nil
+	}), hclog.NewNullLogger())
+
+	do := func(ctx context.Context, method, uri string) string {
+		req, _ := http.NewRequestWithContext(ctx, method, "https://test.okta.com"+uri, nil)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, uri, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if method == http.MethodGet && resp.Header.Get("Link") == "" {
+			t.Fatalf("expected the Link header of %s to be kept for pagination", uri)
+		}
+		return string(body)
+	}
+	ctx := context.Background()
+
+	// every page is cached under its own URL
+	for i := 0; i < 2; i++ {
+		if body := do(ctx, http.MethodGet, "/api/v1/groups"); body != "/api/v1/groups" {
+			t.Fatalf("unexpected body %q", body)
+		}
+		if body := do(ctx, http.MethodGet, "/api/v1/groups?after=2"); body != "/api/v1/groups?after=2" {
+			t.Fatalf("unexpected body %q", body)
+		}
+		do(ctx, http.MethodGet, "/api/v1/users/00u1/groups")
+		do(ctx, http.MethodGet, "/api/v1/apps/0oa1")
+		do(ctx, http.MethodGet, "/api/v1/apps/missing")
+	}
+	for _, uri := range []string{"/api/v1/groups", "/api/v1/groups?after=2", "/api/v1/users/00u1/groups", "/api/v1/apps/0oa1"} {
+		if made["GET "+uri] != 1 {
+			t.Fatalf("expected GET %s to be made once, made %d times", uri, made["GET "+uri])
+		}
+	}
+	if made["GET /api/v1/apps/missing"] != 2 {
+		t.Fatalf("expected the not found response not to be cached")
+	}
+
+	// polling skips the cache
+	do(SkipCache(ctx), http.MethodGet, "/api/v1/apps/0oa1")
+	if made["GET /api/v1/apps/0oa1"] != 2 {
+		t.Fatalf("expected GET /api/v1/apps/0oa1 to skip the cache")
+	}
+
+	// the change invalidates the groups and the groups of the user, not the apps
+	do(ctx, http.MethodPut, "/api/v1/groups/00g1/users/00u1")
+	do(ctx, http.MethodGet, "/api/v1/groups")
+	do(ctx, http.MethodGet, "/api/v1/groups?after=2")
+	do(ctx, http.MethodGet, "/api/v1/users/00u1/groups")
+	do(ctx, http.MethodGet, "/api/v1/apps/0oa1")
+	for uri, expected := range map[string]int{
+		"/api/v1/groups":            2,
+		"/api/v1/groups?after=2":    2,
+		"/api/v1/users/00u1/groups": 2,
+		"/api/v1/apps/0oa1":         2,
+	} {
+		if made["GET "+uri] != expected {
+			t.Fatalf("expected GET %s to be made %d times, made %d times", uri, expected, made["GET "+uri])
+		}
+	}
+
+	// deleting a user invalidates the members of the groups and apps, and
+	// deleting a group the groups of the users
+	for _, uri := range []string{"/api/v1/groups/00g1/users", "/api/v1/apps/0oa1/users", "/api/v1/users/00u2/groups"} {
+		do(ctx, http.MethodGet, uri)
+	}
+	do(ctx, http.MethodDelete, "/api/v1/users/00u1")
+	for _, uri := range []string{"/api/v1/groups/00g1/users", "/api/v1/apps/0oa1/users", "/api/v1/users/00u2/groups"} {
+		do(ctx, http.MethodGet, uri)
+	}
+	do(ctx, http.MethodDelete, "/api/v1/groups/00g2")
+	for _, uri := range []string{"/api/v1/groups/00g1/users", "/api/v1/apps/0oa1/users", "/api/v1/users/00u2/groups"} {
+		do(ctx, http.MethodGet, uri)
+	}
+	for uri, expected := range map[string]int{
+		"/api/v1/groups/00g1/users": 3,
+		"/api/v1/apps/0oa1/users":   2,
+		"/api/v1/users/00u2/groups": 3,
+	} {
+		if made["GET "+uri] != expected {
+			t.Fatalf("expected GET %s to be made %d times, made %d times", uri, expected, made["GET "+uri])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+// ErrCircuitOpen is returned, wrapped, for the requests refused by an open
+// circuit.
+var ErrCircuitOpen = errors.New("the Okta API circuit breaker is open")
+
+// CircuitBreakerTransport stops making requests to an endpoint class after a
+// number of consecutive server errors or timeouts, so an apply fails fast with
+// a clear error during an Okta incident rather than wearing the API down one
+// resource at a time. After a cool down the circuit is half-open, a single
+// probe request is made and its outcome closes or reopens the circuit.
+type CircuitBreakerTransport struct {
+	base            http.RoundTripper
+	apiMutex        *apimutex.APIMutex
+	threshold       int
+	classThresholds map[string]int
+	cooldown        time.Duration
+	logger          hclog.Logger
+	lock            sync.Mutex
+	circuits        map[string]*circuit
+	now             func() time.Time
+}
+
+// circuit is the state of the circuit of an endpoint class, it is closed if
+// openedAt is zero.
+type circuit struct {
+	failures int
+	last     string
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreakerTransport returns a circuit breaker transport that opens the
+// circuit of an endpoint class after threshold consecutive server errors or
+// timeouts, classThresholds sets the threshold of specific endpoint classes. A
+// threshold of 0 disables the circuit breaker of the class.
+func NewCircuitBreakerTransport(base http.RoundTripper, apiMutex *apimutex.APIMutex, threshold int, classThresholds map[string]int, cooldown time.Duration, logger hclog.Logger) *CircuitBreakerTransport {
+	return &CircuitBreakerTransport{
+		base:            base,
+		apiMutex:        apiMutex,
+		threshold:       threshold,
+		classThresholds: classThresholds,
+		cooldown:        cooldown,
+		logger:          logger,
+		circuits:        make(map[string]*circuit),
+		now:             time.Now,
+	}
+}
+
+// RoundTrip makes the request through the base transport unless the circuit of
+// its endpoint class is open.
+func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	class := t.apiMutex.Class(req.Method, req.URL.Path)
+	threshold := t.threshold
+	if classThreshold, ok := t.classThresholds[class]; ok {
+		threshold = classThreshold
+	}
+	if threshold <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	probe, err := t.allow(class)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	failed, reason := isServerFailure(resp, err)
+	t.record(class, threshold, probe, failed, reason)
+	return resp, err
+}
+
+// allow returns an error if the circuit of the class is open, and whether the
+// request is the probe of a half-open circuit.
+func (t *CircuitBreakerTransport) allow(class string) (bool, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	c, ok := t.circuits[class]
+	if !ok || c.openedAt.IsZero() {
+		return false, nil
+	}
+	until := c.openedAt.Add(t.cooldown)
+	if t.now().Before(until) || c.probing {
+		return false, fmt.Errorf("%w for the %q endpoint class after %d consecutive server errors or timeouts, the last one: %s; failing fast until %s",
+			ErrCircuitOpen, class, c.failures, c.last, until.Format(time.RFC3339))
+	}
+	c.probing = true
+	t.logger.Info(fmt.Sprintf("Okta API circuit breaker for the %q endpoint class is half-open, probing with a single request", class))
+	return true, nil
+}
+
+// record accounts for the outcome of a request made in the class.
+func (t *CircuitBreakerTransport) record(class string, threshold int, probe, failed bool, reason string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	c, ok := t.circuits[class]
+	if !ok {
+		c = &circuit{}
+		t.circuits[class] = c
+	}
+	if probe {
+		c.probing = false
+	}
+	if !failed {
+		if !c.openedAt.IsZero() {
+			t.logger.Info(fmt.Sprintf("Okta API circuit breaker for the %q endpoint class is closed", class))
+		}
+		c.failures = 0
+		c.openedAt = time.Time{}
+		return
+	}
+
+	c.failures++
+	c.last = reason
+	// a failure of a request made before the circuit opened doesn't extend
+	// the cool down, only a failed probe reopens it
+	if probe || (c.openedAt.IsZero() && c.failures >= threshold) {
+		c.openedAt = t.now()
+		t.logger.Warn(fmt.Sprintf("Okta API circuit breaker for the %q endpoint class is open after %d consecutive server errors or timeouts, the last one: %s; failing fast for %s",
+			class, c.failures, reason, t.cooldown))
+	}
+}
+
+// isServerFailure reports whether the outcome of a request tells the Okta API
+// is failing, that is a 5xx response or a timeout, and describes it.
+func isServerFailure(resp *http.Response, err error) (bool, string) {
+	if err != nil {
+		var netErr net.Error
+		if (errors.As(err, &netErr) && netErr.Timeout()) || errors.Is(err, context.DeadlineExceeded) {
+			return true, err.Error()
+		}
+		return false, ""
+	}
+	if resp != nil && resp.StatusCode >= http.StatusInternalServerError {
+		return true, resp.Status
+	}
+	return false, ""
+}
@@ -0,0 +1,139 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	made := 0
+	apiMutex, _ := apimutex.NewAPIMutex(100)
+	transport := NewCircuitBreakerTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		made++
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), apiMutex, 3, map[string]int{apimutex.USERS_KEY: 0}, 30*time.Second, hclog.NewNullLogger())
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	get := func(path string) error {
+		req, _ := http.NewRequest(http.MethodGet, "https://test.okta.com"+path, nil)
+		_, err := transport.RoundTrip(req)
+		return err
+	}
+
+	// the circuit opens after 3 consecutive server errors
+	for i := 0; i < 3; i++ {
+		if err := get("/api/v1/groups"); err != nil {
+			t.Fatalf("request %d shouldn't be refused, got %+v", i, err)
+		}
+	}
+	err := get("/api/v1/groups")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to be open, got %+v", err)
+	}
+	if !strings.Contains(err.Error(), `"groups" endpoint class after 3 consecutive`) {
+		t.Fatalf("expected the error to name the endpoint class, got %q", err.Error())
+	}
+	if made != 3 {
+		t.Fatalf("expected 3 requests to be made, got %d", made)
+	}
+
+	// other classes aren't affected, and a threshold of 0 disables the circuit
+	// breaker of a class
+	if err := get("/api/v1/apps"); err != nil {
+		t.Fatalf("apps requests shouldn't be refused, got %+v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := get("/api/v1/users"); err != nil {
+			t.Fatalf("users requests shouldn't be refused, got %+v", err)
+		}
+	}
+
+	// after the cool down a failed probe reopens the circuit
+	now = now.Add(31 * time.Second)
+	made = 0
+	if err := get("/api/v1/groups"); err != nil {
+		t.Fatalf("the probe shouldn't be refused, got %+v", err)
+	}
+	if err := get("/api/v1/groups"); !errors.Is(err, ErrCircuitOpen) || made != 1 {
+		t.Fatalf("expected the circuit to reopen after a failed probe, got %+v", err)
+	}
+
+	// a successful probe closes it
+	now = now.Add(31 * time.Second)
+	status = http.StatusOK
+	for i := 0; i < 3; i++ {
+		if err := get("/api/v1/groups"); err != nil {
+			t.Fatalf("request %d shouldn't be refused after a successful probe, got %+v", i, err)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	apiMutex, _ := apimutex.NewAPIMutex(100)
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	fail := true
+	transport := NewCircuitBreakerTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if fail {
+			return nil, context.DeadlineExceeded
+		}
+		close(probing)
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), apiMutex, 1, nil, time.Second, hclog.NewNullLogger())
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	req, _ := http.NewRequest(http.MethodGet, "https://test.okta.com/api/v1/apps", nil)
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the timeout, got %+v", err)
+	}
+	now = now.Add(2 * time.Second)
+	fail = false
+
+	done := make(chan error)
+	go func() {
+		_, err := transport.RoundTrip(req)
+		done <- err
+	}()
+	<-probing
+	if _, err := transport.RoundTrip(req); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected requests to be refused while the probe is in flight, got %+v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("the probe had error %+v", err)
+	}
+}
+
+func TestIsServerFailure(t *testing.T) {
+	tests := []struct {
+		resp   *http.Response
+		err    error
+		failed bool
+	}{
+		{resp: &http.Response{StatusCode: http.StatusInternalServerError}, failed: true},
+		{resp: &http.Response{StatusCode: http.StatusBadGateway}, failed: true},
+		{resp: &http.Response{StatusCode: http.StatusTooManyRequests}, failed: false},
+		{resp: &http.Response{StatusCode: http.StatusNotFound}, failed: false},
+		{err: context.DeadlineExceeded, failed: true},
+		{err: context.Canceled, failed: false},
+		{err: errors.New("connection refused"), failed: false},
+	}
+	for i, tc := range tests {
+		if failed, _ := isServerFailure(tc.resp, tc.err); failed != tc.failed {
+			t.Errorf("case %d: expected failed to be %t, got %t", i, tc.failed, failed)
+		}
+	}
+}
@@ -21,18 +21,19 @@ const (
 type GovernedTransport struct {
 	base     http.RoundTripper
 	apiMutex *apimutex.APIMutex
+	pacer    *pacer
 	logger   hclog.Logger
 }
 
 // NewGovernedTransport returns a governed transport that relies on pre- and post-
 // requests from the http round tripper. The pre request consults the api mutex
-// to determine if sleeping for the Okta API one minute bucket is called for.
-// The post request updates the information it is holding about the current api
-// rate limits.
+// to pace requests across the Okta API one minute bucket. The post request
+// updates the information it is holding about the current api rate limits.
 func NewGovernedTransport(base http.RoundTripper, apiMutex *apimutex.APIMutex, logger hclog.Logger) *GovernedTransport {
 	return &GovernedTransport{
 		base:     base,
 		apiMutex: apiMutex,
+		pacer:    newPacer(),
 		logger:   logger,
 	}
 }
@@ -56,32 +57,46 @@ func (t *GovernedTransport) RoundTrip(req *http.Request) (*http.Response, error)
 }
 
 func (t *GovernedTransport) preRequestHook(ctx context.Context, method, path string) error {
-	if t.apiMutex.HasCapacity(method, path) {
+	status := t.apiMutex.Status(method, path)
+	wait := t.pacer.reserve(status, t.apiMutex.Capacity(), time.Now())
+	t.apiMutex.Consume(method, path)
+	if wait <= 0 {
 		return nil
 	}
 
-	status := t.apiMutex.Status(method, path)
-	now := time.Now().Unix()
-	timeToSleep := status.Reset() - now
-
-	line := fmt.Sprintf("Throttling API requests; sleeping for %d seconds until rate limit reset (path class %q: %d remaining of %d total); current request \"%s %s\"",
-		timeToSleep,
+	line := fmt.Sprintf("Pacing API requests; waiting %s for a time slot (path class %q: %d remaining of %d total, reset at %d); current request \"%s %s\"",
+		wait.Round(time.Millisecond),
 		status.Class(),
 		status.Remaining(),
 		status.Limit(),
+		status.Reset(),
 		method,
 		path,
 	)
-	t.logger.Info(line)
+	if wait < time.Second {
+		t.logger.Debug(line)
+	} else {
+		t.logger.Info(line)
+	}
 
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.NewTimer(time.Second * time.Duration(timeToSleep)).C:
+	case <-timer.C:
 		return nil
 	}
 }
 
+// LogStats logs the wait time statistics of the requests that have gone
+// through the transport, per endpoint class.
+func (t *GovernedTransport) LogStats() {
+	for _, line := range t.pacer.summary() {
+		t.logger.Info(line)
+	}
+}
+
 func (t *GovernedTransport) postRequestHook(method, path string, resp *http.Response) {
 	if resp == nil {
 		return
@@ -0,0 +1,95 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is how long before its expiry an access token is replaced,
+// so a request doesn't go out with a token that expires on the way.
+const tokenExpiryMargin = time.Minute
+
+// MintFunc mints an OAuth 2.0 access token and returns it with its expiry.
+type MintFunc func(ctx context.Context) (string, time.Time, error)
+
+// OAuthTransport authenticates the requests made through it with an access
+// token it mints, and mints a new one when the token is about to expire or the
+// API rejects it, so long running applies outlive the lifetime of a token.
+type OAuthTransport struct {
+	base      http.RoundTripper
+	mint      MintFunc
+	tokenPath string
+	lock      sync.Mutex
+	token     string
+	expiry    time.Time
+	now       func() time.Time
+}
+
+// NewOAuthTransport returns an OAuth transport minting its access tokens with
+// mint. Requests to tokenPath, the token endpoint, are passed through as is.
+func NewOAuthTransport(base http.RoundTripper, tokenPath string, mint MintFunc) *OAuthTransport {
+	return &OAuthTransport{
+		base:      base,
+		mint:      mint,
+		tokenPath: tokenPath,
+		now:       time.Now,
+	}
+}
+
+// RoundTrip makes the request with the current access token, if the API
+// rejects the token the request is made once more with a new one.
+func (t *OAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == t.tokenPath {
+		return t.base.RoundTrip(req)
+	}
+	token, err := t.currentToken(req.Context(), "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(withToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// the token was revoked or expired earlier than announced, the request is
+	// retried once with a new token if its body can be sent again
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	token, err = t.currentToken(req.Context(), token)
+	if err != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+	return t.base.RoundTrip(withToken(retry, token))
+}
+
+// currentToken returns the current access token, minting a new one if there
+// is none, it is about to expire or it is the rejected token.
+func (t *OAuthTransport) currentToken(ctx context.Context, rejected string) (string, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.token != "" && t.token != rejected && t.now().Add(tokenExpiryMargin).Before(t.expiry) {
+		return t.token, nil
+	}
+	token, expiry, err := t.mint(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to mint an OAuth 2.0 access token: %w", err)
+	}
+	t.token, t.expiry = token, expiry
+	return token, nil
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
@@ -0,0 +1,93 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuthTransport(t *testing.T) {
+	minted := 0
+	now := time.Now()
+	mint := func(ctx context.Context) (string, time.Time, error) {
+		minted++
+		return fmt.Sprintf("token-%d", minted), now.Add(time.Hour), nil
+	}
+	valid := "token-1"
+	var bodies []string
+	transport := NewOAuthTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Body != nil {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+		}
+		status := http.StatusOK
+		if req.URL.Path != "/oauth2/v1/token" && req.Header.Get("Authorization") != "Bearer "+valid {
+			status = http.StatusUnauthorized
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), "/oauth2/v1/token", mint)
+	transport.now = func() time.Time { return now }
+
+	do := func(method, path, body string) int {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req, _ := http.NewRequest(method, "https://test.okta.com"+path, reader)
+		req.Header.Set("Authorization", "Bearer placeholder")
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("round trip had error %+v", err)
+		}
+		return resp.StatusCode
+	}
+
+	// the token is minted once and reused
+	for i := 0; i < 3; i++ {
+		if status := do(http.MethodGet, "/api/v1/groups", ""); status != http.StatusOK {
+			t.Fatalf("expected the request to be authorized, got %d", status)
+		}
+	}
+	if minted != 1 {
+		t.Fatalf("expected a single token to be minted, minted %d", minted)
+	}
+
+	// a new token is minted ahead of the expiry
+	now = now.Add(59*time.Minute + 30*time.Second)
+	valid = "token-2"
+	if status := do(http.MethodGet, "/api/v1/groups", ""); status != http.StatusOK || minted != 2 {
+		t.Fatalf("expected a new token before the expiry, got %d after minting %d", status, minted)
+	}
+
+	// a rejected token is replaced and the request is made again, body included
+	valid = "token-3"
+	bodies = nil
+	if status := do(http.MethodPost, "/api/v1/groups", `{"profile":{"name":"test"}}`); status != http.StatusOK || minted != 3 {
+		t.Fatalf("expected the request to be retried with a new token, got %d after minting %d", status, minted)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Fatalf("expected the body to be sent twice, got %+v", bodies)
+	}
+
+	// token requests are passed through
+	if status := do(http.MethodPost, "/oauth2/v1/token", "grant_type=client_credentials"); status != http.StatusOK || minted != 3 {
+		t.Fatalf("expected the token request to be passed through, got %d after minting %d", status, minted)
+	}
+}
+
+func TestOAuthTransportMintError(t *testing.T) {
+	transport := NewOAuthTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("no request should be made without a token")
+		return nil, nil
+	}), "/oauth2/v1/token", func(ctx context.Context) (string, time.Time, error) {
+		return "", time.Time{}, fmt.Errorf("invalid private key")
+	})
+	req, _ := http.NewRequest(http.MethodGet, "https://test.okta.com/api/v1/groups", nil)
+	if _, err := transport.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "invalid private key") {
+		t.Fatalf("expected the minting error, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package transport
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+// pacer schedules requests so the budget left in an Okta API one minute
+// window is spread evenly over the rest of that window, rather than letting
+// requests run until the budget is used up and then having all of them wake
+// at the reset. Each endpoint class has its own queue of time slots, slots are
+// handed out in the order requests arrive so waiting requests are served
+// fairly.
+type pacer struct {
+	lock  sync.Mutex
+	next  map[string]time.Time
+	stats map[string]*waitStats
+}
+
+// waitStats holds the wait times of the requests of one endpoint class.
+type waitStats struct {
+	requests int
+	delayed  int
+	total    time.Duration
+	max      time.Duration
+}
+
+func newPacer() *pacer {
+	return &pacer{
+		next:  make(map[string]time.Time),
+		stats: make(map[string]*waitStats),
+	}
+}
+
+// reserve returns how long a request in the given status's endpoint class has
+// to wait for its time slot. capacity is the percentage of the limit the
+// provider is allowed to use.
+func (p *pacer) reserve(status *apimutex.APIStatus, capacity int, now time.Time) time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	class := status.Class()
+	wait := p.slot(class, status, capacity, now)
+
+	stats, ok := p.stats[class]
+	if !ok {
+		stats = &waitStats{}
+		p.stats[class] = stats
+	}
+	stats.requests++
+	if wait > 0 {
+		stats.delayed++
+		stats.total += wait
+		if wait > stats.max {
+			stats.max = wait
+		}
+	}
+	return wait
+}
+
+func (p *pacer) slot(class string, status *apimutex.APIStatus, capacity int, now time.Time) time.Duration {
+	reset := time.Unix(status.Reset(), 0)
+	// nothing is known about the current window, the whole budget is left
+	if status.Budget() <= 0 || !reset.After(now) {
+		return 0
+	}
+
+	allowance := float64(status.Budget()) * float64(capacity) / 100.0
+	if allowance <= 0 {
+		return 0
+	}
+	left := allowance - float64(status.Used())
+
+	var interval time.Duration
+	slot := now
+	if next, ok := p.next[class]; ok && next.After(slot) {
+		slot = next
+	}
+	if left > 0 && slot.Before(reset) {
+		// the requests that already have a slot are accounted for in left, see
+		// APIMutex.Consume
+		interval = time.Duration(float64(reset.Sub(slot)) / left)
+	} else {
+		// the budget of this window is used up, pace the requests across the
+		// next window starting at the reset
+		interval = time.Duration(float64(time.Minute) / allowance)
+		if slot.Before(reset) {
+			slot = reset
+		}
+	}
+	p.next[class] = slot.Add(interval)
+	return slot.Sub(now)
+}
+
+// summary returns a line per endpoint class with the wait time statistics of
+// its requests.
+func (p *pacer) summary() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	classes := make([]string, 0, len(p.stats))
+	for class := range p.stats {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	lines := make([]string, 0, len(classes))
+	for _, class := range classes {
+		s := p.stats[class]
+		var avg time.Duration
+		if s.delayed > 0 {
+			avg = s.total / time.Duration(s.delayed)
+		}
+		lines = append(lines, fmt.Sprintf("API wait time statistics for path class %q: %d requests, %d delayed, total wait %s, average wait %s, max wait %s",
+			class, s.requests, s.delayed, s.total.Round(time.Millisecond), avg.Round(time.Millisecond), s.max.Round(time.Millisecond)))
+	}
+	return lines
+}
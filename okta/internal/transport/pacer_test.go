@@ -0,0 +1,73 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+func TestPacerSpreadsBudget(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(40 * time.Second).Unix()
+	path := "/api/v1/groups"
+	apiMutex, _ := apimutex.NewAPIMutex(50)
+	// 50% of 100 is 50, 30 used, 20 left to spread over the next ~40 seconds
+	apiMutex.Update(http.MethodGet, path, 100, 70, reset)
+	interval := time.Duration(float64(time.Unix(reset, 0).Sub(now)) / 20)
+
+	p := newPacer()
+	var previous time.Duration
+	for i := 0; i < 5; i++ {
+		status := apiMutex.Status(http.MethodGet, path)
+		wait := p.reserve(status, apiMutex.Capacity(), now)
+		apiMutex.Consume(http.MethodGet, path)
+		if i == 0 && wait != 0 {
+			t.Fatalf("first request shouldn't wait, waited %s", wait)
+		}
+		if diff := wait - previous - interval; i > 0 && (diff > time.Millisecond || diff < -time.Millisecond) {
+			t.Fatalf("request %d should wait %s after the previous one, waited %s", i, interval, wait-previous)
+		}
+		previous = wait
+	}
+}
+
+func TestPacerWaitsForReset(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(10 * time.Second).Unix()
+	path := "/api/v1/users"
+	apiMutex, _ := apimutex.NewAPIMutex(50)
+	// 50% of 120 is 60, 60 used, nothing left until the reset
+	apiMutex.Update(http.MethodGet, path, 120, 60, reset)
+	status := apiMutex.Status(http.MethodGet, path)
+
+	p := newPacer()
+	first := p.reserve(status, apiMutex.Capacity(), now)
+	if expected := time.Unix(reset, 0).Sub(now); first != expected {
+		t.Fatalf("first request should wait until the reset %s, waited %s", expected, first)
+	}
+	// the rest are spread across the next window rather than all waking at
+	// the reset
+	second := p.reserve(status, apiMutex.Capacity(), now)
+	if second-first != time.Second {
+		t.Fatalf("second request should wait 1s after the first, waited %s", second-first)
+	}
+
+	lines := p.summary()
+	if len(lines) != 1 || !strings.Contains(lines[0], `"users": 2 requests, 2 delayed`) {
+		t.Fatalf("unexpected wait time statistics %+v", lines)
+	}
+}
+
+func TestPacerUnknownWindow(t *testing.T) {
+	apiMutex, _ := apimutex.NewAPIMutex(50)
+	status := apiMutex.Status(http.MethodGet, "/api/v1/apps")
+	p := newPacer()
+	for i := 0; i < 3; i++ {
+		if wait := p.reserve(status, apiMutex.Capacity(), time.Now()); wait != 0 {
+			t.Fatalf("requests shouldn't wait when nothing is known about the window, waited %s", wait)
+		}
+	}
+}
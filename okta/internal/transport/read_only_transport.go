@@ -0,0 +1,34 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ReadOnlyTransport rejects every request that could change the org, so the
+// provider can refresh and plan but never apply a change, regardless of the
+// scopes of its credentials.
+type ReadOnlyTransport struct {
+	base http.RoundTripper
+}
+
+// NewReadOnlyTransport returns a read only transport on top of the base
+// transport.
+func NewReadOnlyTransport(base http.RoundTripper) *ReadOnlyTransport {
+	return &ReadOnlyTransport{base: base}
+}
+
+// RoundTrip makes GET and HEAD requests, and the token requests of OAuth 2.0
+// authentication, through the base transport and rejects all the others.
+func (t *ReadOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case req.Method == http.MethodGet, req.Method == http.MethodHead:
+	case req.Method == http.MethodPost && req.URL.Path == "/oauth2/v1/token":
+		// minting an access token doesn't change the org
+	default:
+		return nil, fmt.Errorf("the provider is in read only mode, %s refused to make the request \"%s %s\"; "+
+			"set read_only = false in the provider configuration to allow changes",
+			ResourceType(req.Context()), req.Method, req.URL.Path)
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	var made []string
+	transport := NewReadOnlyTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		made = append(made, req.Method+" "+req.URL.Path)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}))
+
+	ctx := WithResourceType(context.Background(), "okta_group")
+	tests := []struct {
+		method  string
+		path    string
+		allowed bool
+	}{
+		{method: http.MethodGet, path: "/api/v1/groups", allowed: true},
+		{method: http.MethodHead, path: "/api/v1/groups", allowed: true},
+		{method: http.MethodPost, path: "/oauth2/v1/token", allowed: true},
+		{method: http.MethodPost, path: "/api/v1/groups", allowed: false},
+		{method: http.MethodPut, path: "/api/v1/groups/00g1abcdefghijklmnop", allowed: false},
+		{method: http.MethodDelete, path: "/api/v1/groups/00g1abcdefghijklmnop", allowed: false},
+		{method: http.MethodPost, path: "/api/v1/groups/00g1abcdefghijklmnop/lifecycle/activate", allowed: false},
+	}
+	for _, tc := range tests {
+		made = nil
+		req, _ := http.NewRequestWithContext(ctx, tc.method, "https://test.okta.com"+tc.path, nil)
+		_, err := transport.RoundTrip(req)
+		if tc.allowed && (err != nil || len(made) != 1) {
+			t.Errorf("expected \"%s %s\" to be made, got error %v", tc.method, tc.path, err)
+		}
+		if !tc.allowed {
+			if err == nil || len(made) != 0 {
+				t.Errorf("expected \"%s %s\" to be refused", tc.method, tc.path)
+				continue
+			}
+			if !strings.Contains(err.Error(), "okta_group") || !strings.Contains(err.Error(), tc.method+" "+tc.path) {
+				t.Errorf("expected the error to name the resource and endpoint, got %q", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+type contextKey string
+
+const (
+	resourceTypeKey contextKey = "resource-type"
+	attemptsKey     contextKey = "attempts"
+
+	// unknownResourceType is reported for the requests made outside of a
+	// resource or data source, e.g. while configuring the provider
+	unknownResourceType = "provider"
+)
+
+// WithResourceType returns a context carrying the type of the resource or data
+// source making requests with it, the telemetry transport accounts for the
+// requests per resource type.
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey, resourceType)
+}
+
+// ResourceType returns the resource type carried by the context.
+func ResourceType(ctx context.Context) string {
+	if resourceType, ok := ctx.Value(resourceTypeKey).(string); ok {
+		return resourceType
+	}
+	return unknownResourceType
+}
+
+// TelemetryTransport writes a JSON line to the telemetry file for every round
+// trip made through it. It is meant to sit on top of the transport stack, so a
+// round trip includes the time spent pacing and retrying the request.
+type TelemetryTransport struct {
+	base     http.RoundTripper
+	apiMutex *apimutex.APIMutex
+	lock     sync.Mutex
+	file     *os.File
+	encoder  *json.Encoder
+	calls    map[string]*callCount
+	logger   hclog.Logger
+}
+
+// callCount holds the number of requests made by a resource type.
+type callCount struct {
+	Requests int `json:"requests"`
+	Retries  int `json:"retries"`
+}
+
+type telemetryRecord struct {
+	Type               string  `json:"type"`
+	Time               string  `json:"time"`
+	ResourceType       string  `json:"resource_type"`
+	Method             string  `json:"method"`
+	Class              string  `json:"class"`
+	Status             int     `json:"status,omitempty"`
+	Error              string  `json:"error,omitempty"`
+	LatencyMS          float64 `json:"latency_ms"`
+	Retries            int     `json:"retries"`
+	RateLimitRemaining *int    `json:"rate_limit_remaining,omitempty"`
+}
+
+type telemetrySummary struct {
+	Type          string                `json:"type"`
+	Time          string                `json:"time"`
+	ResourceTypes map[string]*callCount `json:"resource_types"`
+}
+
+// NewTelemetryTransport returns a telemetry transport appending to the file at
+// path. The api mutex classifies the requests in the same endpoint classes the
+// rate limits are accounted for in.
+func NewTelemetryTransport(base http.RoundTripper, path string, apiMutex *apimutex.APIMutex, logger hclog.Logger) (*TelemetryTransport, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open telemetry file: %v", err)
+	}
+	return &TelemetryTransport{
+		base:     base,
+		apiMutex: apiMutex,
+		file:     file,
+		encoder:  json.NewEncoder(file),
+		calls:    make(map[string]*callCount),
+		logger:   logger,
+	}, nil
+}
+
+// RoundTrip makes the request through the base transport and writes its
+// telemetry record.
+func (t *TelemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts := 0
+	req = req.WithContext(context.WithValue(req.Context(), attemptsKey, &attempts))
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	record := telemetryRecord{
+		Type:         "request",
+		Time:         start.UTC().Format(time.RFC3339Nano),
+		ResourceType: ResourceType(req.Context()),
+		Method:       req.Method,
+		Class:        t.apiMutex.Class(req.Method, req.URL.Path),
+		LatencyMS:    float64(latency.Microseconds()) / 1000.0,
+	}
+	if attempts > 1 {
+		record.Retries = attempts - 1
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if resp != nil {
+		record.Status = resp.StatusCode
+		if remaining, err := strconv.Atoi(resp.Header.Get(X_RATE_LIMIT_REMAINING)); err == nil {
+			record.RateLimitRemaining = &remaining
+		}
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	calls, ok := t.calls[record.ResourceType]
+	if !ok {
+		calls = &callCount{}
+		t.calls[record.ResourceType] = calls
+	}
+	calls.Requests++
+	calls.Retries += record.Retries
+	// telemetry is best effort, it never fails a request
+	_ = t.encoder.Encode(record)
+
+	return resp, err
+}
+
+// Close logs and writes the summary of the requests made per resource type and
+// closes the telemetry file.
+func (t *TelemetryTransport) Close() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.file == nil {
+		return
+	}
+	for _, line := range t.summary() {
+		t.logger.Info(line)
+	}
+	_ = t.encoder.Encode(telemetrySummary{
+		Type:          "summary",
+		Time:          time.Now().UTC().Format(time.RFC3339Nano),
+		ResourceTypes: t.calls,
+	})
+	_ = t.file.Close()
+	t.file = nil
+}
+
+// summary returns a line per resource type with the number of requests it
+// made, busiest first. Must be called while holding the lock.
+func (t *TelemetryTransport) summary() []string {
+	resourceTypes := make([]string, 0, len(t.calls))
+	for resourceType := range t.calls {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		a, b := t.calls[resourceTypes[i]], t.calls[resourceTypes[j]]
+		if a.Requests != b.Requests {
+			return a.Requests > b.Requests
+		}
+		return resourceTypes[i] < resourceTypes[j]
+	})
+	lines := make([]string, 0, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		c := t.calls[resourceType]
+		lines = append(lines, fmt.Sprintf("API requests made by %s: %d requests, %d retries", resourceType, c.Requests, c.Retries))
+	}
+	return lines
+}
+
+// attemptTransport counts the attempts made for a request of the telemetry
+// transport, it goes below the retrying client so every retry goes through it.
+type attemptTransport struct {
+	base http.RoundTripper
+}
+
+// NewAttemptTransport returns a transport counting the attempts made to send
+// the requests of a telemetry transport.
+func NewAttemptTransport(base http.RoundTripper) http.RoundTripper {
+	return &attemptTransport{base: base}
+}
+
+func (t *attemptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if attempts, ok := req.Context().Value(attemptsKey).(*int); ok {
+		*attempts++
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,105 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTelemetryTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	apiMutex, _ := apimutex.NewAPIMutex(100)
+
+	statuses := []int{http.StatusInternalServerError, http.StatusOK}
+	server := NewAttemptTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		header := http.Header{}
+		header.Set(X_RATE_LIMIT_REMAINING, "42")
+		return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}))
+	// retries the request once, like the retryable http client would
+	retrying := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if _, err := server.RoundTrip(req); err != nil {
+			return nil, err
+		}
+		return server.RoundTrip(req)
+	})
+	transport, err := NewTelemetryTransport(retrying, path, apiMutex, hclog.NewNullLogger())
+	if err != nil {
+		t.Fatalf("telemetry transport constructor had error %+v", err)
+	}
+
+	ctx := WithResourceType(context.Background(), "okta_group_memberships")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://test.okta.com/api/v1/groups/00g1abcdefghijklmnop/users", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("round trip had error %+v", err)
+	}
+	transport.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening the telemetry file had error %+v", err)
+	}
+	defer file.Close()
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("telemetry line %q isn't JSON: %+v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected a request and a summary line, got %+v", lines)
+	}
+
+	request := lines[0]
+	expected := map[string]interface{}{
+		"type":                 "request",
+		"resource_type":        "okta_group_memberships",
+		"method":               http.MethodGet,
+		"class":                apimutex.GROUPS_KEY,
+		"status":               float64(http.StatusOK),
+		"retries":              float64(1),
+		"rate_limit_remaining": float64(42),
+	}
+	for k, v := range expected {
+		if request[k] != v {
+			t.Errorf("expected request %s to be %v, got %v", k, v, request[k])
+		}
+	}
+
+	summary := lines[1]
+	calls, _ := summary["resource_types"].(map[string]interface{})["okta_group_memberships"].(map[string]interface{})
+	if summary["type"] != "summary" || calls["requests"] != float64(1) || calls["retries"] != float64(1) {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	if resourceType := ResourceType(context.Background()); resourceType != unknownResourceType {
+		t.Errorf("expected %q for a context without a resource type, got %q", unknownResourceType, resourceType)
+	}
+	ctx := WithResourceType(context.Background(), "data.okta_group")
+	if resourceType := ResourceType(ctx); resourceType != "data.okta_group" {
+		t.Errorf("expected %q, got %q", "data.okta_group", resourceType)
+	}
+}
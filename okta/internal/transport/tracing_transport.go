@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+)
+
+// TracingTransport starts a client span for every request made through it, as
+// a child of the span in the request's context. It is meant to sit on top of
+// the transport stack, so a span includes the time spent pacing and retrying
+// the request.
+type TracingTransport struct {
+	base     http.RoundTripper
+	tracer   trace.Tracer
+	apiMutex *apimutex.APIMutex
+}
+
+// NewTracingTransport returns a tracing transport starting its spans with the
+// tracer. The api mutex classifies the requests in the same endpoint classes
+// the rate limits are accounted for in.
+func NewTracingTransport(base http.RoundTripper, tracer trace.Tracer, apiMutex *apimutex.APIMutex) *TracingTransport {
+	return &TracingTransport{
+		base:     base,
+		tracer:   tracer,
+		apiMutex: apiMutex,
+	}
+}
+
+// RoundTrip makes the request through the base transport in its own span.
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPURLKey.String(req.URL.String()),
+			attribute.String("okta.endpoint_class", t.apiMutex.Class(req.Method, req.URL.Path)),
+			attribute.String("terraform.resource_type", ResourceType(req.Context())),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if remaining, err := strconv.Atoi(resp.Header.Get(X_RATE_LIMIT_REMAINING)); err == nil {
+		span.SetAttributes(attribute.Int("okta.rate_limit_remaining", remaining))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,219 @@
+package okta
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/youmark/pkcs8"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	oauthTokenPath = "/oauth2/v1/token"
+	// oauthTokenPlaceholder is the token the Okta SDK is configured with when
+	// the provider authenticates with a private key, the OAuth transport
+	// replaces it with the current access token
+	oauthTokenPlaceholder = "minted-by-the-oauth-transport"
+)
+
+// privateKeyContent returns the private key of the config, from
+// private_key_file or private_key. For backwards compatibility private_key can
+// also be the path of a key file.
+func (c *Config) privateKeyContent() (string, error) {
+	if c.privateKeyFile != "" {
+		content, err := ioutil.ReadFile(c.privateKeyFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read private key file: %v", err)
+		}
+		return string(content), nil
+	}
+	if _, err := os.Stat(c.privateKey); err == nil {
+		content, err := ioutil.ReadFile(c.privateKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to read private key file: %v", err)
+		}
+		return string(content), nil
+	}
+	return c.privateKey, nil
+}
+
+// parsePrivateKey parses a private key in PEM, PKCS#1 or PKCS#8 for RSA keys,
+// SEC 1 or PKCS#8 for EC keys, optionally encrypted with the passphrase, or a
+// JWK. Returns the signing key of the client assertions and the key ID the
+// JWK carries.
+func parsePrivateKey(key, passphrase string) (jose.SigningKey, string, error) {
+	key = strings.TrimSpace(strings.ReplaceAll(key, `\n`, "\n"))
+	if strings.HasPrefix(key, "{") {
+		var jwk jose.JSONWebKey
+		if err := jwk.UnmarshalJSON([]byte(key)); err != nil {
+			return jose.SigningKey{}, "", fmt.Errorf("invalid JWK private key: %v", err)
+		}
+		if jwk.IsPublic() {
+			return jose.SigningKey{}, "", errors.New("the JWK is a public key, expecting a private key")
+		}
+		signingKey, err := signingKeyFor(jwk.Key)
+		return signingKey, jwk.KeyID, err
+	}
+
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return jose.SigningKey{}, "", errors.New("invalid private key, expecting a PEM encoded key or a JWK")
+	}
+	der := block.Bytes
+	// legacy encrypted PEM, still what `openssl genrsa -aes256` writes
+	if x509.IsEncryptedPEMBlock(block) {
+		if passphrase == "" {
+			return jose.SigningKey{}, "", errors.New("the private key is encrypted, private_key_passphrase is required")
+		}
+		var err error
+		der, err = x509.DecryptPEMBlock(block, []byte(passphrase))
+		if err != nil {
+			return jose.SigningKey{}, "", fmt.Errorf("failed to decrypt private key: %v", err)
+		}
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(der)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(der)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(der)
+	case "ENCRYPTED PRIVATE KEY":
+		if passphrase == "" {
+			return jose.SigningKey{}, "", errors.New("the private key is encrypted, private_key_passphrase is required")
+		}
+		parsed, err = pkcs8.ParsePKCS8PrivateKey(der, []byte(passphrase))
+	default:
+		return jose.SigningKey{}, "", fmt.Errorf("unsupported private key PEM type %q", block.Type)
+	}
+	if err != nil {
+		return jose.SigningKey{}, "", fmt.Errorf("failed to parse private key: %v", err)
+	}
+	signingKey, err := signingKeyFor(parsed)
+	return signingKey, "", err
+}
+
+// signingKeyFor returns the signing key of an RSA or EC private key, with the
+// algorithm Okta expects for it.
+func signingKeyFor(key interface{}) (jose.SigningKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jose.SigningKey{Algorithm: jose.RS256, Key: k}, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.SigningKey{Algorithm: jose.ES256, Key: k}, nil
+		case elliptic.P384():
+			return jose.SigningKey{Algorithm: jose.ES384, Key: k}, nil
+		case elliptic.P521():
+			return jose.SigningKey{Algorithm: jose.ES512, Key: k}, nil
+		}
+		return jose.SigningKey{}, fmt.Errorf("unsupported EC private key curve %s", k.Curve.Params().Name)
+	}
+	return jose.SigningKey{}, fmt.Errorf("unsupported private key type %T, expecting an RSA or EC key", key)
+}
+
+// accessTokenResponse is the response of the token endpoint.
+type accessTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// accessTokenMinter returns a function minting access tokens for the config's
+// service app, authenticated with a client assertion signed with its private
+// key. The key is parsed when the first token is minted.
+func (c *Config) accessTokenMinter(orgURL string, client *http.Client) func(ctx context.Context) (string, time.Time, error) {
+	var signer jose.Signer
+	tokenURL := orgURL + oauthTokenPath
+	return func(ctx context.Context) (string, time.Time, error) {
+		if signer == nil {
+			key, err := c.privateKeyContent()
+			if err != nil {
+				return "", time.Time{}, err
+			}
+			signingKey, keyID, err := parsePrivateKey(key, c.keyPassphrase)
+			if err != nil {
+				return "", time.Time{}, err
+			}
+			if c.privateKeyId != "" {
+				keyID = c.privateKeyId
+			}
+			opts := &jose.SignerOptions{}
+			if keyID != "" {
+				opts = opts.WithHeader("kid", keyID)
+			}
+			signer, err = jose.NewSigner(signingKey, opts.WithType("JWT"))
+			if err != nil {
+				return "", time.Time{}, err
+			}
+		}
+
+		jti := make([]byte, 16)
+		if _, err := rand.Read(jti); err != nil {
+			return "", time.Time{}, err
+		}
+		now := time.Now()
+		// the claims of the client assertion, see
+		// https://developer.okta.com/docs/guides/implement-oauth-for-okta-serviceapp/main/
+		assertion, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:   c.clientID,
+			Subject:  c.clientID,
+			Audience: jwt.Audience{tokenURL},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(5 * time.Minute)),
+			ID:       hex.EncodeToString(jti),
+		}).CompactSerialize()
+		if err != nil {
+			return "", time.Time{}, err
+		}
+
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+		form.Set("scope", strings.Join(c.scopes, " "))
+		form.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+		form.Set("client_assertion", assertion)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		defer resp.Body.Close()
+
+		var token accessTokenResponse
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil && resp.StatusCode == http.StatusOK {
+			return "", time.Time{}, fmt.Errorf("invalid token response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+			return "", time.Time{}, fmt.Errorf("token request failed with %s: %s %s", resp.Status, token.Error, token.ErrorDescription)
+		}
+		return token.AccessToken, now.Add(time.Duration(token.ExpiresIn) * time.Second), nil
+	}
+}
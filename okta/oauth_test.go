@@ -0,0 +1,133 @@
+package okta
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youmark/pkcs8"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	pemEncode := func(blockType string, der []byte) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+	}
+	pkcs8RSA, _ := x509.MarshalPKCS8PrivateKey(rsaKey)
+	sec1EC, _ := x509.MarshalECPrivateKey(ecKey)
+	encryptedPKCS8, _ := pkcs8.ConvertPrivateKeyToPKCS8(ecKey, []byte("secret"))
+	// legacy encrypted PEM, as written by openssl genrsa -aes256
+	legacyBlock, _ := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), []byte("secret"), x509.PEMCipherAES256)
+	jwk, _ := jose.JSONWebKey{Key: ecKey, KeyID: "jwk-kid"}.MarshalJSON()
+	publicJWK, _ := jose.JSONWebKey{Key: ecKey.Public()}.MarshalJSON()
+
+	tests := []struct {
+		name       string
+		key        string
+		passphrase string
+		algorithm  jose.SignatureAlgorithm
+		keyID      string
+		err        string
+	}{
+		{name: "PKCS#1 RSA", key: pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), algorithm: jose.RS256},
+		{name: "escaped new lines", key: strings.ReplaceAll(pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)), "\n", `\n`), algorithm: jose.RS256},
+		{name: "PKCS#8 RSA", key: pemEncode("PRIVATE KEY", pkcs8RSA), algorithm: jose.RS256},
+		{name: "SEC 1 EC", key: pemEncode("EC PRIVATE KEY", sec1EC), algorithm: jose.ES384},
+		{name: "encrypted PKCS#8 EC", key: pemEncode("ENCRYPTED PRIVATE KEY", encryptedPKCS8), passphrase: "secret", algorithm: jose.ES384},
+		{name: "encrypted PKCS#8 without passphrase", key: pemEncode("ENCRYPTED PRIVATE KEY", encryptedPKCS8), err: "private_key_passphrase is required"},
+		{name: "encrypted PKCS#8 wrong passphrase", key: pemEncode("ENCRYPTED PRIVATE KEY", encryptedPKCS8), passphrase: "wrong", err: "failed to parse private key"},
+		{name: "legacy encrypted PEM", key: string(pem.EncodeToMemory(legacyBlock)), passphrase: "secret", algorithm: jose.RS256},
+		{name: "legacy encrypted PEM without passphrase", key: string(pem.EncodeToMemory(legacyBlock)), err: "private_key_passphrase is required"},
+		{name: "JWK", key: string(jwk), algorithm: jose.ES384, keyID: "jwk-kid"},
+		{name: "public JWK", key: string(publicJWK), err: "public key"},
+		{name: "garbage", key: "privateKey", err: "invalid private key"},
+	}
+	for _, test := range tests {
+		signingKey, keyID, err := parsePrivateKey(test.key, test.passphrase)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("test %q: expected error containing %q, got %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %q: did not expect error but received error: %+v", test.name, err)
+			continue
+		}
+		if signingKey.Algorithm != test.algorithm || keyID != test.keyID {
+			t.Errorf("test %q: expected %s with key ID %q, got %s with key ID %q", test.name, test.algorithm, test.keyID, signingKey.Algorithm, keyID)
+		}
+	}
+}
+
+func TestAccessTokenMinter(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalECPrivateKey(key)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var tokenURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != oauthTokenPath || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if scope := r.PostForm.Get("scope"); scope != "okta.groups.manage okta.users.read" {
+			t.Errorf("unexpected scope %q", scope)
+		}
+		assertion, err := jwt.ParseSigned(r.PostForm.Get("client_assertion"))
+		if err != nil {
+			t.Fatalf("invalid client assertion: %+v", err)
+		}
+		if kid := assertion.Headers[0].KeyID; kid != "key-id" {
+			t.Errorf("expected the key ID header, got %q", kid)
+		}
+		var claims jwt.Claims
+		if err := assertion.Claims(&key.PublicKey, &claims); err != nil {
+			t.Fatalf("client assertion signature doesn't verify: %+v", err)
+		}
+		if err := claims.Validate(jwt.Expected{Issuer: "client-id", Subject: "client-id", Audience: jwt.Audience{tokenURL}, Time: time.Now()}); err != nil {
+			t.Errorf("invalid client assertion claims: %+v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token_type":"Bearer","expires_in":3600,"access_token":"minted-token"}`))
+	}))
+	defer server.Close()
+	tokenURL = server.URL + oauthTokenPath
+
+	config := &Config{
+		clientID:       "client-id",
+		privateKeyFile: keyFile,
+		privateKeyId:   "key-id",
+		scopes:         []string{"okta.groups.manage", "okta.users.read"},
+	}
+	mint := config.accessTokenMinter(server.URL, server.Client())
+	token, expiry, err := mint(context.Background())
+	if err != nil {
+		t.Fatalf("minting had error %+v", err)
+	}
+	if token != "minted-token" {
+		t.Errorf("expected the minted token, got %q", token)
+	}
+	if remaining := time.Until(expiry); remaining < 59*time.Minute || remaining > time.Hour {
+		t.Errorf("expected the token to expire in an hour, expires in %s", remaining)
+	}
+}
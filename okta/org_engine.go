@@ -0,0 +1,118 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	// oieOnlyResources are the resources and data sources which are only
+	// available in orgs on the Okta Identity Engine (OIE)
+	oieOnlyResources = map[string]bool{
+		appSignOnPolicy:             true,
+		appSignOnPolicyRule:         true,
+		authenticator:               true,
+		captcha:                     true,
+		captchaOrgWideSettings:      true,
+		deviceAssurancePolicy:       true,
+		policyProfileEnrollment:     true,
+		policyProfileEnrollmentApps: true,
+		policyRuleProfileEnrollment: true,
+	}
+
+	// oieOnlyAttributes are the attributes of resources which are only
+	// available in OIE orgs
+	oieOnlyAttributes = map[string][]string{
+		appOAuth:         {"authentication_policy"},
+		appSaml:          {"authentication_policy"},
+		policyMfa:        {"is_oie"},
+		policyMfaDefault: {"is_oie"},
+	}
+
+	// classicOnlyResources are the resources which are only available in
+	// orgs on the Classic Engine
+	classicOnlyResources = map[string]bool{
+		factor: true,
+	}
+)
+
+// checkOrgEngine makes the plan of a resource fail when it, or one of its
+// attributes, is only available in OIE orgs and the org of the provider is on
+// the Classic Engine. The resources only available in Classic orgs are let
+// through with a warning, since Okta still accepts some of their settings.
+func checkOrgEngine(name string, r *schema.Resource) {
+	attributes := oieOnlyAttributes[name]
+	switch {
+	case oieOnlyResources[name] || len(attributes) > 0:
+		f := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if config, ok := m.(*Config); ok && config.classicOrg {
+				if oieOnlyResources[name] {
+					return fmt.Errorf("%s is only available in orgs on the Okta Identity Engine, the org of the provider is on the Classic Engine", name)
+				}
+				for _, attribute := range attributes {
+					if _, ok := d.GetOk(attribute); ok {
+						return fmt.Errorf("%s of %s is only available in orgs on the Okta Identity Engine, the org of the provider is on the Classic Engine", attribute, name)
+					}
+				}
+			}
+			if f != nil {
+				return f(ctx, d, m)
+			}
+			return nil
+		}
+	case classicOnlyResources[name]:
+		f := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if config, ok := m.(*Config); ok && config.oieOrg {
+				logger(m).Warn(classicOnlyWarning(name))
+			}
+			if f != nil {
+				return f(ctx, d, m)
+			}
+			return nil
+		}
+		if f := r.CreateContext; f != nil {
+			r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				diags := f(ctx, d, m)
+				if config, ok := m.(*Config); ok && config.oieOrg {
+					diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: classicOnlyWarning(name)})
+				}
+				return diags
+			}
+		}
+	}
+}
+
+// checkDataSourceOrgEngine makes the read of a data source which is only
+// available in OIE orgs fail before calling the API when the org is on the
+// Classic Engine.
+func checkDataSourceOrgEngine(name string, r *schema.Resource) {
+	f := r.ReadContext
+	if !oieOnlyResources[name] || f == nil {
+		return
+	}
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if config, ok := m.(*Config); ok && config.classicOrg {
+			return diag.Errorf("data source %s is only available in orgs on the Okta Identity Engine, the org of the provider is on the Classic Engine", name)
+		}
+		return f(ctx, d, m)
+	}
+}
+
+// checkFrameworkOrgEngine is checkOrgEngine for the framework resources, which
+// call it from their ModifyPlan.
+func checkFrameworkOrgEngine(name string, config *Config, diags *fwdiag.Diagnostics) {
+	if config != nil && config.classicOrg && oieOnlyResources[name] {
+		diags.AddError("Identity Engine only resource",
+			fmt.Sprintf("%s is only available in orgs on the Okta Identity Engine, the org of the provider is on the Classic Engine", name))
+	}
+}
+
+func classicOnlyWarning(name string) string {
+	return fmt.Sprintf("%s is meant for orgs on the Classic Engine, the org of the provider is on the Okta Identity Engine where it may not work as expected", name)
+}
@@ -0,0 +1,69 @@
+package okta
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestCheckOrgEngine(t *testing.T) {
+	p := Provider()
+	classic := &Config{classicOrg: true, logger: hclog.NewNullLogger()}
+	oie := &Config{oieOrg: true, logger: hclog.NewNullLogger()}
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		meta    *Config
+		message string
+	}{
+		{
+			name:    authenticator,
+			config:  map[string]interface{}{"key": "security_question", "name": "Security Question"},
+			meta:    classic,
+			message: "okta_authenticator is only available in orgs on the Okta Identity Engine",
+		},
+		{
+			name:   authenticator,
+			config: map[string]interface{}{"key": "security_question", "name": "Security Question"},
+			meta:   oie,
+		},
+		{
+			name:    policyMfa,
+			config:  map[string]interface{}{"name": "mfa", "is_oie": true},
+			meta:    classic,
+			message: "is_oie of okta_policy_mfa is only available in orgs on the Okta Identity Engine",
+		},
+		{
+			name:   policyMfa,
+			config: map[string]interface{}{"name": "mfa"},
+			meta:   classic,
+		},
+		{
+			name:   factor,
+			config: map[string]interface{}{"provider_id": "google_otp"},
+			meta:   oie,
+		},
+	}
+	for _, test := range tests {
+		r := p.ResourcesMap[test.name]
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), test.meta)
+		switch {
+		case test.message == "" && err != nil:
+			t.Errorf("expected %s to be planned, got %v", test.name, err)
+		case test.message != "" && (err == nil || !strings.Contains(err.Error(), test.message)):
+			t.Errorf("expected %s to fail with %q, got %v", test.name, test.message, err)
+		}
+	}
+}
+
+func TestCheckDataSourceOrgEngine(t *testing.T) {
+	r := Provider().DataSourcesMap[authenticator]
+	d := r.TestResourceData()
+	diags := r.ReadContext(context.Background(), d, &Config{classicOrg: true, logger: hclog.NewNullLogger()})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "data source okta_authenticator is only available") {
+		t.Fatalf("expected the read to fail on a Classic org, got %v", diags)
+	}
+}
@@ -0,0 +1,149 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// job is a unit of work run by runJobs, name describes the work in errors,
+// e.g. "add user (00u1) to group (00g1)".
+type job struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// jobResult is the outcome of a job, err is nil when the job succeeded.
+type jobResult struct {
+	name string
+	err  error
+}
+
+// setChangeJob is a job adding value to or removing it from a set attribute,
+// it lets the attribute be set to what actually happened when some of the
+// jobs fail.
+type setChangeJob struct {
+	job
+	value interface{}
+	add   bool
+}
+
+// runJobs runs the jobs on a pool of parallelism workers, a worker picks up
+// the next job as soon as it is done with the previous one. Once ctx is done,
+// e.g. on Ctrl-C, the jobs that haven't been started yet aren't run and fail
+// with the context's error. It returns a result per job, in the order of the
+// jobs.
+func runJobs(ctx context.Context, parallelism int, jobs []job) []jobResult {
+	results := make([]jobResult, len(jobs))
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(jobs) {
+		parallelism = len(jobs)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				j := jobs[index]
+				err := ctx.Err()
+				if err != nil {
+					err = fmt.Errorf("not started: %w", err)
+				} else {
+					err = j.run(ctx)
+				}
+				results[index] = jobResult{name: j.name, err: err}
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// runSetChangeJobs runs the jobs changing the set attribute key with the
+// provider's parallelism and records in key what actually happened, see
+// setAfterJobs.
+func runSetChangeJobs(ctx context.Context, d *schema.ResourceData, m interface{}, key string, planned *schema.Set, jobs []setChangeJob) []jobResult {
+	results := runJobs(ctx, getParallelismFromMetadata(m), setChangeJobsToJobs(jobs))
+	setAfterJobs(d, key, planned, jobs, results)
+	return results
+}
+
+func setChangeJobsToJobs(jobs []setChangeJob) []job {
+	res := make([]job, len(jobs))
+	for i := range jobs {
+		res[i] = jobs[i].job
+	}
+	return res
+}
+
+// setAfterJobs sets key to the planned set with the changes of the failed
+// jobs undone, so the next plan tries them again. It leaves key alone when
+// all the jobs succeeded.
+func setAfterJobs(d *schema.ResourceData, key string, planned *schema.Set, jobs []setChangeJob, results []jobResult) {
+	if !jobsFailed(results) {
+		return
+	}
+	actual := schema.NewSet(planned.F, planned.List())
+	for i, r := range results {
+		if r.err == nil {
+			continue
+		}
+		if jobs[i].add {
+			actual.Remove(jobs[i].value)
+		} else {
+			actual.Add(jobs[i].value)
+		}
+	}
+	_ = d.Set(key, actual)
+}
+
+func jobsFailed(results []jobResult) bool {
+	for _, r := range results {
+		if r.err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// jobsDiagnostics returns an error diagnostic per failed job.
+func jobsDiagnostics(results []jobResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, r := range results {
+		if r.err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("failed to %s", r.name),
+				Detail:   r.err.Error(),
+			})
+		}
+	}
+	return diags
+}
+
+// jobsError returns an error listing the failed jobs, one per line, or nil
+// when all of them succeeded.
+func jobsError(results []jobResult, message string) error {
+	var errList []string
+	for _, r := range results {
+		if r.err != nil {
+			errList = append(errList, fmt.Sprintf("failed to %s: %v", r.name, r.err))
+		}
+	}
+	if len(errList) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s, %d of %d changes failed:\n%s", message, len(errList), len(results), strings.Join(errList, "\n"))
+}
@@ -0,0 +1,104 @@
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRunJobsKeepsWorkersBusy(t *testing.T) {
+	var running, maxRunning int32
+	release := make(chan struct{})
+	// the first job is slow, the others have to run past it
+	jobs := []job{{name: "slow", run: func(ctx context.Context) error {
+		<-release
+		return nil
+	}}}
+	var done int32
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, job{name: fmt.Sprintf("job %d", i), run: func(ctx context.Context) error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			if atomic.AddInt32(&done, 1) == 10 {
+				close(release)
+			}
+			return nil
+		}})
+	}
+	results := runJobs(context.Background(), 3, jobs)
+	if len(results) != len(jobs) {
+		t.Fatalf("expected %d results, got %d", len(jobs), len(results))
+	}
+	for i, r := range results {
+		if r.err != nil || r.name != jobs[i].name {
+			t.Fatalf("unexpected result %d: %+v", i, r)
+		}
+	}
+	if maxRunning > 2 {
+		t.Fatalf("expected at most 2 fast jobs at a time next to the slow one, got %d", maxRunning)
+	}
+}
+
+func TestRunJobsStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var ran int32
+	jobs := make([]job, 5)
+	for i := range jobs {
+		jobs[i] = job{name: fmt.Sprintf("job %d", i), run: func(ctx context.Context) error {
+			atomic.AddInt32(&ran, 1)
+			cancel()
+			return errors.New("interrupted")
+		}}
+	}
+	results := runJobs(ctx, 1, jobs)
+	if ran != 1 {
+		t.Fatalf("expected the jobs after the cancellation not to run, %d ran", ran)
+	}
+	for _, r := range results[1:] {
+		if !errors.Is(r.err, context.Canceled) {
+			t.Fatalf("expected %s to fail with the context's error, got %v", r.name, r.err)
+		}
+	}
+	if diags := jobsDiagnostics(results); len(diags) != len(jobs) || diags[0].Summary != "failed to job 0" {
+		t.Fatalf("expected a diagnostic per job, got %+v", diags)
+	}
+}
+
+func TestSetAfterJobs(t *testing.T) {
+	d := resourceGroupMemberships().TestResourceData()
+	planned := schema.NewSet(schema.HashString, []interface{}{"a", "b"})
+	fail := func(ctx context.Context) error { return errors.New("failed") }
+	succeed := func(ctx context.Context) error { return nil }
+	jobs := []setChangeJob{
+		{job: job{name: "add a", run: succeed}, value: "a", add: true},
+		{job: job{name: "add b", run: fail}, value: "b", add: true},
+		{job: job{name: "remove c", run: fail}, value: "c"},
+		{job: job{name: "remove d", run: succeed}, value: "d"},
+	}
+	results := runJobs(context.Background(), 2, setChangeJobsToJobs(jobs))
+	setAfterJobs(d, "users", planned, jobs, results)
+
+	actual := convertInterfaceToStringSetNullable(d.Get("users"))
+	if len(actual) != 2 || !contains(actual, "a") || !contains(actual, "c") {
+		t.Fatalf("expected the users to be what actually happened [a c], got %v", actual)
+	}
+	err := jobsError(results, "failed to update group members")
+	if err == nil || !strings.Contains(err.Error(), "2 of 4 changes failed") ||
+		!strings.Contains(err.Error(), "failed to add b: failed\nfailed to remove c: failed") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
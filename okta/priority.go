@@ -0,0 +1,92 @@
+package okta
+
+import "fmt"
+
+// priorityMove sets the priority of the object with the ID, priorities start
+// at 1 and setting one shifts the objects at and after it down by one.
+type priorityMove struct {
+	id       string
+	priority int64
+}
+
+// priorityMoves returns the fewest priority updates which turn the current
+// order of IDs into the desired one. The objects of the longest subsequence of
+// the desired order which is already in order stay where they are, the others
+// are moved right after their predecessor in the desired order.
+func priorityMoves(current, desired []string) ([]priorityMove, error) {
+	position := make(map[string]int, len(current))
+	for i, id := range current {
+		position[id] = i
+	}
+	listed := make(map[string]bool, len(desired))
+	for _, id := range desired {
+		if listed[id] {
+			return nil, fmt.Errorf("%s is listed more than once", id)
+		}
+		if _, ok := position[id]; !ok {
+			return nil, fmt.Errorf("%s doesn't exist", id)
+		}
+		listed[id] = true
+	}
+	for _, id := range current {
+		if !listed[id] {
+			return nil, fmt.Errorf("%s isn't listed, the order must have all of them", id)
+		}
+	}
+	keep := longestInOrder(desired, position)
+	order := append([]string(nil), current...)
+	var moves []priorityMove
+	for i, id := range desired {
+		if keep[id] {
+			continue
+		}
+		order = removeString(order, id)
+		at := 0
+		if i > 0 {
+			at = indexOfString(order, desired[i-1]) + 1
+		}
+		order = append(order[:at], append([]string{id}, order[at:]...)...)
+		moves = append(moves, priorityMove{id: id, priority: int64(at + 1)})
+	}
+	return moves, nil
+}
+
+// longestInOrder returns the IDs of the longest subsequence of ids whose
+// positions are increasing.
+func longestInOrder(ids []string, position map[string]int) map[string]bool {
+	length := make([]int, len(ids))
+	prev := make([]int, len(ids))
+	last := -1
+	for i := range ids {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if position[ids[j]] < position[ids[i]] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if last == -1 || length[i] > length[last] {
+			last = i
+		}
+	}
+	keep := make(map[string]bool)
+	for i := last; i >= 0; i = prev[i] {
+		keep[ids[i]] = true
+	}
+	return keep
+}
+
+func indexOfString(s []string, v string) int {
+	for i := range s {
+		if s[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeString(s []string, v string) []string {
+	if i := indexOfString(s, v); i >= 0 {
+		return append(s[:i], s[i+1:]...)
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package okta
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPriorityMoves(t *testing.T) {
+	tests := []struct {
+		current, desired string
+		moves            int
+	}{
+		{current: "a b c d", desired: "a b c d", moves: 0},
+		{current: "a b c d", desired: "b c d a", moves: 1},
+		{current: "a b c d", desired: "d a b c", moves: 1},
+		{current: "a b c d", desired: "d c b a", moves: 3},
+		{current: "a b c d e", desired: "b a d c e", moves: 2},
+		{current: "c a e b d", desired: "a b c d e", moves: 2},
+		{current: "a", desired: "a", moves: 0},
+	}
+	for _, test := range tests {
+		current, desired := strings.Fields(test.current), strings.Fields(test.desired)
+		moves, err := priorityMoves(current, desired)
+		if err != nil {
+			t.Fatalf("unexpected error ordering %q as %q: %v", test.current, test.desired, err)
+		}
+		if len(moves) != test.moves {
+			t.Errorf("expected %d moves ordering %q as %q, got %v", test.moves, test.current, test.desired, moves)
+		}
+		// apply the moves the way Okta does, shifting the others down
+		order := current
+		for _, move := range moves {
+			order = removeString(order, move.id)
+			at := int(move.priority - 1)
+			order = append(order[:at], append([]string{move.id}, order[at:]...)...)
+		}
+		if got := strings.Join(order, " "); got != test.desired {
+			t.Errorf("expected the moves to order %q as %q, got %q", test.current, test.desired, got)
+		}
+	}
+}
+
+func TestPriorityMovesInvalid(t *testing.T) {
+	tests := []struct {
+		desired, message string
+	}{
+		{desired: "a b b", message: "b is listed more than once"},
+		{desired: "a b x", message: "x doesn't exist"},
+		{desired: "a c", message: "b isn't listed, the order must have all of them"},
+	}
+	for _, test := range tests {
+		_, err := priorityMoves([]string{"a", "b", "c"}, strings.Fields(test.desired))
+		if err == nil || err.Error() != test.message {
+			t.Errorf("expected %q ordering %q, got %v", test.message, test.desired, err)
+		}
+	}
+}
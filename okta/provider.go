@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
 	"github.com/okta/terraform-provider-okta/okta/internal/mutexkv"
 )
 
@@ -31,6 +34,7 @@ const (
 	appOAuthAPIScope              = "okta_app_oauth_api_scope"
 	appOAuthPostLogoutRedirectURI = "okta_app_oauth_post_logout_redirect_uri"
 	appOAuthRedirectURI           = "okta_app_oauth_redirect_uri"
+	appOAuthRoleAssignment        = "okta_app_oauth_role_assignment"
 	appSaml                       = "okta_app_saml"
 	appSamlAppSettings            = "okta_app_saml_app_settings"
 	appSecurePasswordStore        = "okta_app_secure_password_store"
@@ -61,6 +65,7 @@ const (
 	captchaOrgWideSettings        = "okta_captcha_org_wide_settings"
 	defaultPolicies               = "okta_default_policies"
 	defaultPolicy                 = "okta_default_policy"
+	deviceAssurancePolicy         = "okta_device_assurance_policy"
 	domain                        = "okta_domain"
 	domainCertificate             = "okta_domain_certificate"
 	domainVerification            = "okta_domain_verification"
@@ -78,6 +83,8 @@ const (
 	groupEveryone                 = "okta_everyone_group"
 	groupMembership               = "okta_group_membership"
 	groupMemberships              = "okta_group_memberships"
+	groupOwner                    = "okta_group_owner"
+	groupOwners                   = "okta_group_owners"
 	groupRole                     = "okta_group_role"
 	groupRoles                    = "okta_group_roles"
 	groupRule                     = "okta_group_rule"
@@ -91,18 +98,22 @@ const (
 	inlineHook                    = "okta_inline_hook"
 	linkDefinition                = "okta_link_definition"
 	linkValue                     = "okta_link_value"
+	logStream                     = "okta_log_stream"
+	logStreams                    = "okta_log_streams"
 	networkZone                   = "okta_network_zone"
 	orgConfiguration              = "okta_org_configuration"
 	orgSupport                    = "okta_org_support"
 	policy                        = "okta_policy"
 	policyMfa                     = "okta_policy_mfa"
 	policyMfaDefault              = "okta_policy_mfa_default"
+	policyOrder                   = "okta_policy_order"
 	policyPassword                = "okta_policy_password"
 	policyPasswordDefault         = "okta_policy_password_default"
 	policyProfileEnrollment       = "okta_policy_profile_enrollment"
 	policyProfileEnrollmentApps   = "okta_policy_profile_enrollment_apps"
 	policyRuleIdpDiscovery        = "okta_policy_rule_idp_discovery"
 	policyRuleMfa                 = "okta_policy_rule_mfa"
+	policyRuleOrder               = "okta_policy_rule_order"
 	policyRulePassword            = "okta_policy_rule_password"
 	policyRuleProfileEnrollment   = "okta_policy_rule_profile_enrollment"
 	policyRuleSignOn              = "okta_policy_rule_signon"
@@ -112,6 +123,7 @@ const (
 	resourceSet                   = "okta_resource_set"
 	roleSubscription              = "okta_role_subscription"
 	securityNotificationEmails    = "okta_security_notification_emails"
+	systemLog                     = "okta_system_log"
 	templateEmail                 = "okta_template_email"
 	templateSms                   = "okta_template_sms"
 	theme                         = "okta_theme"
@@ -136,7 +148,7 @@ const (
 func Provider() *schema.Provider {
 	deprecatedPolicies := dataSourceDefaultPolicy()
 	deprecatedPolicies.DeprecationMessage = "This data source will be deprecated in favor of okta_default_policy or okta_policy data sources."
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"org_name": {
 				Type:        schema.TypeString,
@@ -149,14 +161,14 @@ func Provider() *schema.Provider {
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("OKTA_ACCESS_TOKEN", nil),
 				Description:   "Bearer token granting privileges to Okta API.",
-				ConflictsWith: []string{"api_token", "client_id", "scopes", "private_key"},
+				ConflictsWith: []string{"api_token", "client_id", "scopes", "private_key", "private_key_file"},
 			},
 			"api_token": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("OKTA_API_TOKEN", nil),
 				Description:   "API Token granting privileges to Okta API.",
-				ConflictsWith: []string{"access_token", "client_id", "scopes", "private_key"},
+				ConflictsWith: []string{"access_token", "client_id", "scopes", "private_key", "private_key_file"},
 			},
 			"client_id": {
 				Type:          schema.TypeString,
@@ -178,7 +190,21 @@ func Provider() *schema.Provider {
 				Type:          schema.TypeString,
 				DefaultFunc:   schema.EnvDefaultFunc("OKTA_API_PRIVATE_KEY", nil),
 				Description:   "API Token granting privileges to Okta API.",
-				ConflictsWith: []string{"access_token", "api_token"},
+				ConflictsWith: []string{"access_token", "api_token", "private_key_file"},
+			},
+			"private_key_file": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				DefaultFunc:   schema.EnvDefaultFunc("OKTA_API_PRIVATE_KEY_FILE", nil),
+				Description:   "Path of the file holding the private key of the service app, instead of private_key.",
+				ConflictsWith: []string{"access_token", "api_token", "private_key"},
+			},
+			"private_key_passphrase": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("OKTA_API_PRIVATE_KEY_PASSPHRASE", nil),
+				Description: "Passphrase of an encrypted private key.",
 			},
 			"private_key_id": {
 				Optional:      true,
@@ -246,6 +272,122 @@ func Provider() *schema.Provider {
 					"capacity while making calls to the Okta management API endpoints. Okta API operates in one minute buckets. " +
 					"See Okta Management API Rate Limits: https://developer.okta.com/docs/reference/rl-global-mgmt/",
 			},
+			"api_rate_limit_overrides": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path_regex": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: stringIsRegex,
+							Description:      "Regular expression matching the path of the endpoints, e.g. `^/api/v1/apps$`.",
+						},
+						"method": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: elemInSlice([]string{"GET", "POST", "PUT", "DELETE"}),
+							Description:      "HTTP method of the endpoints, any method matches if it is not set.",
+						},
+						"limit": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: intAtLeast(1),
+							Description:      "Requests per minute the org admin has set for the endpoints, used until Okta reports their limit.",
+						},
+					},
+				},
+				Description: "(Experimental) custom rate limits set by the org admin. When max_api_capacity is in use, the " +
+					"matching endpoints are accounted for in their own bucket instead of in Okta's default endpoint classes.",
+			},
+			"api_rate_limit_backend": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: elemInSlice([]string{"memory", "file"}),
+				DefaultFunc:      schema.EnvDefaultFunc("OKTA_API_RATE_LIMIT_BACKEND", "memory"),
+				Description: "(Experimental) where the rate limit status is kept when max_api_capacity is in use. With " +
+					"`memory` each provider process only accounts for its own requests, with `file` the provider processes " +
+					"on one machine share the status through api_rate_limit_file.",
+			},
+			"api_rate_limit_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OKTA_API_RATE_LIMIT_FILE", ""),
+				Description: "(Experimental) path of the file shared by the provider processes when api_rate_limit_backend " +
+					"is `file`, the default is a file per org in the temporary directory.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OKTA_READ_ONLY", false),
+				Description: "Only allows requests reading from the Okta org, any request that could change it fails with " +
+					"an error naming the resource and endpoint. Refresh and plan work as usual, apply fails on the first change.",
+			},
+			"api_read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OKTA_API_READ_CACHE", false),
+				Description: "Keeps the responses to Okta API reads in memory for the run of the provider and serves repeated " +
+					"reads of the same URL from memory. A change to an object invalidates the cached responses of its kind of " +
+					"objects and of the objects it concerns.",
+			},
+			"circuit_breaker_threshold": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: intAtLeast(0),
+				DefaultFunc:      schema.EnvDefaultFunc("OKTA_CIRCUIT_BREAKER_THRESHOLD", 0),
+				Description: "Number of consecutive server errors or timeouts from an Okta API endpoint class after which " +
+					"requests to it fail fast, the default is `0` (means the circuit breaker is disabled).",
+			},
+			"circuit_breaker_class_thresholds": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeInt},
+				ValidateDiagFunc: mapKeysInSlice(apimutex.Classes()),
+				Description:      "circuit_breaker_threshold of specific Okta API endpoint classes, `0` disables the circuit breaker of a class.",
+			},
+			"circuit_breaker_cooldown": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: intBetween(1, 600),
+				Description:      "Seconds the circuit breaker fails fast for before a single request probes if the endpoint class has recovered.",
+			},
+			"telemetry_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OKTA_TELEMETRY_FILE", ""),
+				Description: "Path of a file the provider appends a JSON line to for every Okta API request it makes, " +
+					"followed by a summary of the requests made per resource type when the provider shuts down.",
+			},
+			"trace_exporter": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: elemInSlice([]string{traceExporterNone, traceExporterOTLP, traceExporterFile}),
+				DefaultFunc:      schema.EnvDefaultFunc("OKTA_TRACE_EXPORTER", traceExporterNone),
+				Description: "Exporter of the OpenTelemetry traces of the resource operations and their Okta API requests, " +
+					"one of `none`, `otlp` or `file`. The provider's stdout belongs to the plugin protocol, use `file` to " +
+					"write the traces locally.",
+			},
+			"trace_otlp_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+				Description: "URL of the OTLP/HTTP collector the `otlp` trace exporter sends the traces to, e.g. `https://collector:4318`.",
+			},
+			"trace_otlp_headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers the `otlp` trace exporter sends to the collector, e.g. for authentication.",
+			},
+			"trace_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OKTA_TRACE_FILE", "terraform-provider-okta-traces.json"),
+				Description: "Path of the file the `file` trace exporter appends the traces to.",
+			},
 			"request_timeout": {
 				Type:             schema.TypeInt,
 				Optional:         true,
@@ -267,6 +409,7 @@ func Provider() *schema.Provider {
 			appOAuthAPIScope:              resourceAppOAuthAPIScope(),
 			appOAuthPostLogoutRedirectURI: resourceAppOAuthPostLogoutRedirectURI(),
 			appOAuthRedirectURI:           resourceAppOAuthRedirectURI(),
+			appOAuthRoleAssignment:        resourceAppOAuthRoleAssignment(),
 			appSaml:                       resourceAppSaml(),
 			appSamlAppSettings:            resourceAppSamlAppSettings(),
 			appSecurePasswordStore:        resourceAppSecurePasswordStore(),
@@ -303,6 +446,8 @@ func Provider() *schema.Provider {
 			group:                         resourceGroup(),
 			groupMembership:               resourceGroupMembership(),
 			groupMemberships:              resourceGroupMemberships(),
+			groupOwner:                    resourceGroupOwner(),
+			groupOwners:                   resourceGroupOwners(),
 			groupRole:                     resourceGroupRole(),
 			groupRoles:                    resourceGroupRoles(),
 			groupRule:                     resourceGroupRule(),
@@ -319,12 +464,14 @@ func Provider() *schema.Provider {
 			orgSupport:                    resourceOrgSupport(),
 			policyMfa:                     resourcePolicyMfa(),
 			policyMfaDefault:              resourcePolicyMfaDefault(),
+			policyOrder:                   resourcePolicyOrder(),
 			policyPassword:                resourcePolicyPassword(),
 			policyPasswordDefault:         resourcePolicyPasswordDefault(),
 			policyProfileEnrollment:       resourcePolicyProfileEnrollment(),
 			policyProfileEnrollmentApps:   resourcePolicyProfileEnrollmentApps(),
 			policyRuleIdpDiscovery:        resourcePolicyRuleIdpDiscovery(),
 			policyRuleMfa:                 resourcePolicyMfaRule(),
+			policyRuleOrder:               resourcePolicyRuleOrder(),
 			policyRulePassword:            resourcePolicyPasswordRule(),
 			policyRuleProfileEnrollment:   resourcePolicyProfileEnrollmentRule(),
 			policyRuleSignOn:              resourcePolicySignOnRule(),
@@ -397,6 +544,7 @@ func Provider() *schema.Provider {
 			defaultPolicy:            dataSourceDefaultPolicy(),
 			group:                    dataSourceGroup(),
 			groupEveryone:            dataSourceEveryoneGroup(),
+			groupOwners:              dataSourceGroupOwners(),
 			groups:                   dataSourceGroups(),
 			idpMetadataSaml:          dataSourceIdpMetadataSaml(),
 			idpOidc:                  dataSourceIdpOidc(),
@@ -405,6 +553,7 @@ func Provider() *schema.Provider {
 			networkZone:              dataSourceNetworkZone(),
 			policy:                   dataSourcePolicy(),
 			roleSubscription:         dataSourceRoleSubscription(),
+			systemLog:                dataSourceSystemLog(),
 			theme:                    dataSourceTheme(),
 			themes:                   dataSourceThemes(),
 			trustedOrigins:           dataSourceTrustedOrigins(),
@@ -416,6 +565,15 @@ func Provider() *schema.Provider {
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+	for name, r := range p.ResourcesMap {
+		checkOrgEngine(name, r)
+		instrumentResource(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		checkDataSourceOrgEngine(name, r)
+		instrumentResource("data."+name, r)
+	}
+	return p
 }
 
 func deprecateIncorrectNaming(d *schema.Resource, newResource string) *schema.Resource {
@@ -425,23 +583,69 @@ func deprecateIncorrectNaming(d *schema.Resource, newResource string) *schema.Re
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	log.Printf("[INFO] Initializing Okta client")
+	return configureClients(ctx, newConfig(d))
+}
+
+// newConfig builds the provider config from the provider schema values.
+func newConfig(d *schema.ResourceData) *Config {
 	config := Config{
-		orgName:        d.Get("org_name").(string),
-		domain:         d.Get("base_url").(string),
-		apiToken:       d.Get("api_token").(string),
-		accessToken:    d.Get("access_token").(string),
-		clientID:       d.Get("client_id").(string),
-		privateKey:     d.Get("private_key").(string),
-		privateKeyId:   d.Get("private_key_id").(string),
-		scopes:         convertInterfaceToStringSet(d.Get("scopes")),
-		retryCount:     d.Get("max_retries").(int),
-		parallelism:    d.Get("parallelism").(int),
-		backoff:        d.Get("backoff").(bool),
-		minWait:        d.Get("min_wait_seconds").(int),
-		maxWait:        d.Get("max_wait_seconds").(int),
-		logLevel:       d.Get("log_level").(int),
-		requestTimeout: d.Get("request_timeout").(int),
-		maxAPICapacity: d.Get("max_api_capacity").(int),
+		orgName:           d.Get("org_name").(string),
+		domain:            d.Get("base_url").(string),
+		apiToken:          d.Get("api_token").(string),
+		accessToken:       d.Get("access_token").(string),
+		clientID:          d.Get("client_id").(string),
+		privateKey:        d.Get("private_key").(string),
+		privateKeyId:      d.Get("private_key_id").(string),
+		privateKeyFile:    d.Get("private_key_file").(string),
+		keyPassphrase:     d.Get("private_key_passphrase").(string),
+		scopes:            convertInterfaceToStringSet(d.Get("scopes")),
+		retryCount:        d.Get("max_retries").(int),
+		parallelism:       d.Get("parallelism").(int),
+		backoff:           d.Get("backoff").(bool),
+		minWait:           d.Get("min_wait_seconds").(int),
+		maxWait:           d.Get("max_wait_seconds").(int),
+		logLevel:          d.Get("log_level").(int),
+		requestTimeout:    d.Get("request_timeout").(int),
+		maxAPICapacity:    d.Get("max_api_capacity").(int),
+		telemetryFile:     d.Get("telemetry_file").(string),
+		traceExporter:     d.Get("trace_exporter").(string),
+		traceOTLPEndpoint: d.Get("trace_otlp_endpoint").(string),
+		traceFile:         d.Get("trace_file").(string),
+		readOnly:          d.Get("read_only").(bool),
+		readCache:         d.Get("api_read_cache").(bool),
+
+		circuitBreakerThreshold: d.Get("circuit_breaker_threshold").(int),
+		circuitBreakerCooldown:  d.Get("circuit_breaker_cooldown").(int),
+	}
+
+	if d.Get("api_rate_limit_backend").(string) == "file" {
+		config.rateLimitFile = d.Get("api_rate_limit_file").(string)
+		if config.rateLimitFile == "" {
+			config.rateLimitFile = filepath.Join(os.TempDir(), fmt.Sprintf("terraform-provider-okta-%s.%s-rate-limits.json", config.orgName, config.domain))
+		}
+	}
+
+	if thresholds, ok := d.Get("circuit_breaker_class_thresholds").(map[string]interface{}); ok && len(thresholds) > 0 {
+		config.circuitBreakerClassThresholds = make(map[string]int, len(thresholds))
+		for class, threshold := range thresholds {
+			config.circuitBreakerClassThresholds[class] = threshold.(int)
+		}
+	}
+
+	if headers, ok := d.Get("trace_otlp_headers").(map[string]interface{}); ok && len(headers) > 0 {
+		config.traceOTLPHeaders = make(map[string]string, len(headers))
+		for k, v := range headers {
+			config.traceOTLPHeaders[k] = v.(string)
+		}
+	}
+
+	for _, v := range d.Get("api_rate_limit_overrides").([]interface{}) {
+		o := v.(map[string]interface{})
+		config.rateLimitOverrides = append(config.rateLimitOverrides, rateLimitOverride{
+			pathRegex: o["path_regex"].(string),
+			method:    o["method"].(string),
+			limit:     o["limit"].(int),
+		})
 	}
 
 	if httpProxy, ok := d.Get("http_proxy").(string); ok {
@@ -451,6 +655,12 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	if v := os.Getenv("OKTA_API_SCOPES"); v != "" && len(config.scopes) == 0 {
 		config.scopes = strings.Split(v, ",")
 	}
+	return &config
+}
+
+// configureClients initializes the Okta clients of the config and discovers
+// the kind of org the provider is talking to.
+func configureClients(ctx context.Context, config *Config) (interface{}, diag.Diagnostics) {
 	if err := config.loadAndValidate(ctx); err != nil {
 		return nil, diag.Errorf("[ERROR] invalid configuration: %v", err)
 	}
@@ -458,14 +668,39 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	// Discover if the Okta Org is Classic or OIE
 	if org, _, err := config.supplementClient.GetWellKnownOktaOrganization(ctx); err == nil {
 		config.classicOrg = (org.Pipeline == "v1") // v1 == Classic, idx == OIE
+		config.oieOrg = (org.Pipeline == "idx")
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // This is a global MutexKV for use within this plugin.
 var oktaMutexKV = mutexkv.NewMutexKV()
 
+var (
+	shutdownHooks     []func()
+	shutdownHooksLock sync.Mutex
+)
+
+// registerShutdownHook registers a function to be run by Shutdown.
+func registerShutdownHook(hook func()) {
+	shutdownHooksLock.Lock()
+	defer shutdownHooksLock.Unlock()
+	shutdownHooks = append(shutdownHooks, hook)
+}
+
+// Shutdown runs the registered shutdown hooks. It is called once the plugin
+// server has stopped, which is at the end of a Terraform operation such as an
+// apply.
+func Shutdown() {
+	shutdownHooksLock.Lock()
+	defer shutdownHooksLock.Unlock()
+	for _, hook := range shutdownHooks {
+		hook()
+	}
+	shutdownHooks = nil
+}
+
 func envDefaultSetFunc(k string, dv interface{}) schema.SchemaDefaultFunc {
 	return func() (interface{}, error) {
 		if v := os.Getenv(k); v != "" {
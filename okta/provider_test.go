@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/okta/okta-sdk-golang/v2/okta"
@@ -19,7 +20,10 @@ import (
 
 var (
 	testAccProvidersFactories map[string]func() (*schema.Provider, error)
-	testAccProvider           *schema.Provider
+	// testAccProtoV5ProviderFactories serve the resources of the framework
+	// provider as well, the tests of those resources use them
+	testAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+	testAccProvider                 *schema.Provider
 )
 
 func init() {
@@ -29,6 +33,15 @@ func init() {
 			return testAccProvider, nil
 		},
 	}
+	testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"okta": func() (tfprotov5.ProviderServer, error) {
+			server, err := newProviderServer(context.Background(), testAccProvider)
+			if err != nil {
+				return nil, err
+			}
+			return server(), nil
+		},
+	}
 }
 
 func TestProvider(t *testing.T) {
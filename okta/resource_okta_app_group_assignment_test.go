@@ -6,13 +6,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccAppGroupAssignment_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", appGroupAssignment)
 	resourceName0 := fmt.Sprintf("%s.test.0", appGroupAssignment)
 	resourceName1 := fmt.Sprintf("%s.test.1", appGroupAssignment)
@@ -20,7 +19,7 @@ func TestAccAppGroupAssignment_crud(t *testing.T) {
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("updated.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -95,7 +94,7 @@ func TestAccAppGroupAssignment_crud(t *testing.T) {
 }
 
 func TestAccAppGroupAssignment_retain(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", appGroupAssignment)
 	appName := fmt.Sprintf("%s.test", appOAuth)
 	groupName := fmt.Sprintf("%s.test", group)
@@ -103,7 +102,7 @@ func TestAccAppGroupAssignment_retain(t *testing.T) {
 	retainAssignment := mgr.GetFixtures("retain_assignment.tf", ri, t)
 	retainAssignmentDestroy := mgr.GetFixtures("retain_assignment_destroy.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
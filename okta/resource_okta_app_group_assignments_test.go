@@ -5,13 +5,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccAppGroupAssignments_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", appGroupAssignments)
 	mgr := newFixtureManager(appGroupAssignments)
 	config := mgr.GetFixtures("basic.tf", ri, t)
@@ -21,7 +20,7 @@ func TestAccAppGroupAssignments_crud(t *testing.T) {
 	group2 := fmt.Sprintf("%s.test2", group)
 	group3 := fmt.Sprintf("%s.test3", group)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
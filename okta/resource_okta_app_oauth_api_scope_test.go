@@ -6,13 +6,12 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 )
 
 func TestAccAppOAuthApplication_apiScope(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appOAuthAPIScope)
 	plainConfig := mgr.GetFixtures("basic.tf", ri, t)
 	plainUpdatedConfig := mgr.GetFixtures("basic_updated.tf", ri, t)
@@ -22,7 +21,7 @@ func TestAccAppOAuthApplication_apiScope(t *testing.T) {
 	config := strings.ReplaceAll(plainConfig, "https://your.okta.org", getOktaDomainName())
 	updatedConfig := strings.ReplaceAll(plainUpdatedConfig, "https://your.okta.org", getOktaDomainName())
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appOAuth, createDoesAppExist(okta.NewOpenIdConnectApplication())),
@@ -0,0 +1,306 @@
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceAppOAuthRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppOAuthRoleAssignmentCreate,
+		ReadContext:   resourceAppOAuthRoleAssignmentRead,
+		UpdateContext: resourceAppOAuthRoleAssignmentUpdate,
+		DeleteContext: resourceAppOAuthRoleAssignmentDelete,
+		Importer:      createNestedResourceImporter([]string{"client_id", "id"}),
+		Description:   "Resource to manage the assignment of an admin role to an OAuth service app",
+		CustomizeDiff: customdiff.All(
+			validateAppOAuthRoleAssignment,
+			// to avoid exception when removing last target from a role assignment,
+			// the API consumer should delete the role assignment and recreate it.
+			customdiff.ForceNewIf("apps", func(_ context.Context, d *schema.ResourceDiff, m interface{}) bool {
+				return d.HasChange("apps") && len(convertInterfaceToStringSet(d.Get("apps"))) == 0
+			}),
+			customdiff.ForceNewIf("groups", func(_ context.Context, d *schema.ResourceDiff, m interface{}) bool {
+				return d.HasChange("groups") && len(convertInterfaceToStringSet(d.Get("groups"))) == 0
+			}),
+		),
+		Schema: map[string]*schema.Schema{
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Client ID of the OAuth service app the role is assigned to",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: elemInSlice(append([]string{"CUSTOM"}, validAdminRoles...)),
+				Description:      "Type of the role, a standard role or CUSTOM",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"resource_set"},
+				Description:  "ID of the custom role, when the type is CUSTOM",
+			},
+			"resource_set": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"role"},
+				Description:  "ID of the resource set of the custom role, when the type is CUSTOM",
+			},
+			"apps": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "List of app names (name represents set of app instances) or a combination of app name and app instance ID (like 'salesforce' or 'facebook.0oapsqQ6dv19pqyEo0g3') the APP_ADMIN role is limited to",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"groups"},
+			},
+			"groups": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "List of group IDs the GROUP_MEMBERSHIP_ADMIN, HELP_DESK_ADMIN or USER_ADMIN role is limited to",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"apps"},
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Label of the role",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the role assignment",
+			},
+		},
+	}
+}
+
+func resourceAppOAuthRoleAssignmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientID := d.Get("client_id").(string)
+	roleType := d.Get("type").(string)
+	client := getSupplementFromMetadata(m)
+	logger(m).Info("assigning role to OAuth app", "client_id", clientID, "role_type", roleType)
+	assignment, _, err := client.CreateClientRoleAssignment(ctx, clientID, sdk.ClientRoleAssignment{
+		Type:        roleType,
+		Role:        d.Get("role").(string),
+		ResourceSet: d.Get("resource_set").(string),
+	})
+	if err != nil {
+		return diag.Errorf("failed to assign role %s to OAuth app %s: %v", roleType, clientID, err)
+	}
+	d.SetId(assignment.ID)
+	err = addClientRoleAppTargets(ctx, client, clientID, assignment.ID, convertInterfaceToStringSet(d.Get("apps")))
+	if err != nil {
+		return diag.Errorf("unable to add app targets to role assignment %s for OAuth app %s: %v", assignment.ID, clientID, err)
+	}
+	err = addClientRoleGroupTargets(ctx, client, clientID, assignment.ID, convertInterfaceToStringSet(d.Get("groups")))
+	if err != nil {
+		return diag.Errorf("unable to add group targets to role assignment %s for OAuth app %s: %v", assignment.ID, clientID, err)
+	}
+	return resourceAppOAuthRoleAssignmentRead(ctx, d, m)
+}
+
+func resourceAppOAuthRoleAssignmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientID := d.Get("client_id").(string)
+	client := getSupplementFromMetadata(m)
+	assignment, resp, err := client.GetClientRoleAssignment(ctx, clientID, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to get role '%s' assigned to OAuth app '%s': %v", d.Id(), clientID, err)
+	}
+	if assignment == nil {
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("type", assignment.Type)
+	_ = d.Set("role", assignment.Role)
+	_ = d.Set("resource_set", assignment.ResourceSet)
+	_ = d.Set("label", assignment.Label)
+	_ = d.Set("status", assignment.Status)
+	if assignment.Type == "APP_ADMIN" {
+		apps, err := listClientRoleAppTargets(ctx, m, clientID, d.Id())
+		if err != nil {
+			return diag.Errorf("unable to list app targets for role %s and OAuth app %s: %v", d.Id(), clientID, err)
+		}
+		_ = d.Set("apps", convertStringSliceToSet(apps))
+	} else if supportsGroupTargets(assignment.Type) {
+		groups, err := listClientRoleGroupTargets(ctx, client, clientID, d.Id())
+		if err != nil {
+			return diag.Errorf("unable to list group targets for role %s and OAuth app %s: %v", d.Id(), clientID, err)
+		}
+		_ = d.Set("groups", convertStringSliceToSet(groups))
+	}
+	return nil
+}
+
+func resourceAppOAuthRoleAssignmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientID := d.Get("client_id").(string)
+	client := getSupplementFromMetadata(m)
+	if d.HasChange("apps") {
+		oldApps, newApps := d.GetChange("apps")
+		appsToAdd, appsToRemove := splitTargets(convertInterfaceToStringSet(newApps), convertInterfaceToStringSet(oldApps))
+		err := addClientRoleAppTargets(ctx, client, clientID, d.Id(), appsToAdd)
+		if err != nil {
+			return diag.Errorf("unable to add app targets to role assignment %s for OAuth app %s: %v", d.Id(), clientID, err)
+		}
+		err = removeClientRoleAppTargets(ctx, client, clientID, d.Id(), appsToRemove)
+		if err != nil {
+			return diag.Errorf("failed to remove app targets from role assignment %s of OAuth app %s: %v", d.Id(), clientID, err)
+		}
+	}
+	if d.HasChange("groups") {
+		oldGroups, newGroups := d.GetChange("groups")
+		groupsToAdd, groupsToRemove := splitTargets(convertInterfaceToStringSet(newGroups), convertInterfaceToStringSet(oldGroups))
+		err := addClientRoleGroupTargets(ctx, client, clientID, d.Id(), groupsToAdd)
+		if err != nil {
+			return diag.Errorf("unable to add group targets to role assignment %s for OAuth app %s: %v", d.Id(), clientID, err)
+		}
+		err = removeClientRoleGroupTargets(ctx, client, clientID, d.Id(), groupsToRemove)
+		if err != nil {
+			return diag.Errorf("failed to remove group targets from role assignment %s of OAuth app %s: %v", d.Id(), clientID, err)
+		}
+	}
+	return resourceAppOAuthRoleAssignmentRead(ctx, d, m)
+}
+
+func resourceAppOAuthRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clientID := d.Get("client_id").(string)
+	logger(m).Info("deleting role assigned to OAuth app", "client_id", clientID, "role_type", d.Get("type").(string))
+	resp, err := getSupplementFromMetadata(m).DeleteClientRoleAssignment(ctx, clientID, d.Id())
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to remove role %s assigned to OAuth app %s: %v", d.Id(), clientID, err)
+	}
+	return nil
+}
+
+func validateAppOAuthRoleAssignment(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	roleType := d.Get("type").(string)
+	if roleType == "CUSTOM" {
+		if d.NewValueKnown("role") && d.Get("role").(string) == "" {
+			return errors.New("'role' and 'resource_set' are required when 'type' is 'CUSTOM'")
+		}
+	} else if d.Get("role").(string) != "" || d.Get("resource_set").(string) != "" {
+		return errors.New("'role' and 'resource_set' can only be set when 'type' is 'CUSTOM'")
+	}
+	if len(convertInterfaceToStringSet(d.Get("apps"))) > 0 && roleType != "APP_ADMIN" {
+		return errors.New("'apps' can only be set when 'type' is 'APP_ADMIN'")
+	}
+	if len(convertInterfaceToStringSet(d.Get("groups"))) > 0 && !supportsGroupTargets(roleType) {
+		return errors.New("'groups' can only be set when 'type' is 'GROUP_MEMBERSHIP_ADMIN', 'HELP_DESK_ADMIN' or 'USER_ADMIN'")
+	}
+	return nil
+}
+
+func addClientRoleAppTargets(ctx context.Context, client *sdk.APISupplement, clientID, roleID string, apps []string) error {
+	for i := range apps {
+		app := strings.Split(apps[i], ".")
+		_, err := client.AddClientRoleAppTarget(ctx, clientID, roleID, app[0], strings.Join(app[1:], ""))
+		if err != nil {
+			return fmt.Errorf("failed to add app target '%s': %v", apps[i], err)
+		}
+	}
+	return nil
+}
+
+func removeClientRoleAppTargets(ctx context.Context, client *sdk.APISupplement, clientID, roleID string, apps []string) error {
+	for i := range apps {
+		app := strings.Split(apps[i], ".")
+		resp, err := client.RemoveClientRoleAppTarget(ctx, clientID, roleID, app[0], strings.Join(app[1:], ""))
+		if err := suppressErrorOn404(resp, err); err != nil {
+			return fmt.Errorf("failed to remove app target '%s': %v", apps[i], err)
+		}
+	}
+	return nil
+}
+
+func addClientRoleGroupTargets(ctx context.Context, client *sdk.APISupplement, clientID, roleID string, groups []string) error {
+	for i := range groups {
+		_, err := client.AddClientRoleGroupTarget(ctx, clientID, roleID, groups[i])
+		if err != nil {
+			return fmt.Errorf("failed to add group target '%s': %v", groups[i], err)
+		}
+	}
+	return nil
+}
+
+func removeClientRoleGroupTargets(ctx context.Context, client *sdk.APISupplement, clientID, roleID string, groups []string) error {
+	for i := range groups {
+		resp, err := client.RemoveClientRoleGroupTarget(ctx, clientID, roleID, groups[i])
+		if err := suppressErrorOn404(resp, err); err != nil {
+			return fmt.Errorf("failed to remove group target '%s': %v", groups[i], err)
+		}
+	}
+	return nil
+}
+
+// listClientRoleAppTargets gets the app targets of the role in the format of
+// the apps attribute, the app instances are looked up for their app name.
+func listClientRoleAppTargets(ctx context.Context, m interface{}, clientID, roleID string) ([]string, error) {
+	var resApps []string
+	apps, resp, err := getSupplementFromMetadata(m).ListClientRoleAppTargets(ctx, clientID, roleID, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		for _, app := range apps {
+			if app.Id == "" {
+				resApps = append(resApps, app.Name)
+				continue
+			}
+			a := okta.NewApplication()
+			_, resp, err := getOktaClientFromMetadata(m).Application.GetApplication(ctx, app.Id, a, nil)
+			if err := suppressErrorOn404(resp, err); err != nil {
+				return nil, err
+			}
+			if a.Name == "" {
+				// the app instance has been deleted since
+				continue
+			}
+			resApps = append(resApps, fmt.Sprintf("%s.%s", a.Name, a.Id))
+		}
+		if !resp.HasNextPage() {
+			break
+		}
+		apps = nil
+		resp, err = resp.Next(ctx, &apps)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resApps, nil
+}
+
+func listClientRoleGroupTargets(ctx context.Context, client *sdk.APISupplement, clientID, roleID string) ([]string, error) {
+	var resIDs []string
+	groups, resp, err := client.ListClientRoleGroupTargets(ctx, clientID, roleID, &query.Params{Limit: defaultPaginationLimit})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		for _, group := range groups {
+			resIDs = append(resIDs, group.Id)
+		}
+		if !resp.HasNextPage() {
+			break
+		}
+		groups = nil
+		resp, err = resp.Next(ctx, &groups)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resIDs, nil
+}
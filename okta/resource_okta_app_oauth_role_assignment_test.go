@@ -0,0 +1,181 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/okta-sdk-golang/v2/okta"
+)
+
+func TestAccOktaAppOAuthRoleAssignment_crud(t *testing.T) {
+	ri := vcrRandInt(t)
+	resourceName := fmt.Sprintf("%s.test", appOAuthRoleAssignment)
+	mgr := newFixtureManager(appOAuthRoleAssignment)
+	config := mgr.GetFixtures("basic.tf", ri, t)
+	groupTargets := mgr.GetFixtures("group_targets.tf", ri, t)
+	groupTargetsUpdated := mgr.GetFixtures("group_targets_updated.tf", ri, t)
+	appTargets := mgr.GetFixtures("app_targets.tf", ri, t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      createCheckResourceDestroy(appOAuth, createDoesAppExist(okta.NewOpenIdConnectApplication())),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "READ_ONLY_ADMIN"),
+					resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+					resource.TestCheckResourceAttrSet(resourceName, "label"),
+				),
+			},
+			{
+				Config: groupTargets,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "HELP_DESK_ADMIN"),
+					resource.TestCheckResourceAttr(resourceName, "groups.#", "1"),
+				),
+			},
+			{
+				Config: groupTargetsUpdated,
+				Check:  resource.TestCheckResourceAttr(resourceName, "groups.#", "2"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("failed to find %s", resourceName)
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["client_id"], rs.Primary.ID), nil
+				},
+			},
+			{
+				Config: appTargets,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "APP_ADMIN"),
+					resource.TestCheckResourceAttr(resourceName, "apps.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOktaAppOAuthRoleAssignment_custom(t *testing.T) {
+	ri := vcrRandInt(t)
+	resourceName := fmt.Sprintf("%s.test", appOAuthRoleAssignment)
+	mgr := newFixtureManager(appOAuthRoleAssignment)
+	config := mgr.GetFixtures("custom.tf", ri, t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      createCheckResourceDestroy(appOAuth, createDoesAppExist(okta.NewOpenIdConnectApplication())),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "CUSTOM"),
+					resource.TestCheckResourceAttrPair(resourceName, "role", "okta_admin_role_custom.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_set", "okta_resource_set.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAppOAuthRoleAssignmentLifecycle(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	clientID := srv.Put("/apps", map[string]interface{}{"name": "oidc_client", "label": "testAcc_service"})
+
+	ctx := context.Background()
+	r := resourceAppOAuthRoleAssignment()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"client_id":    clientID,
+		"type":         "CUSTOM",
+		"role":         "cr0testAcc",
+		"resource_set": "iamtestAcc",
+	})
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to assign role to OAuth app: %v", diags)
+	}
+	expected := []string{
+		"POST /oauth2/v1/clients/" + clientID + "/roles",
+		"GET /oauth2/v1/clients/" + clientID + "/roles/" + d.Id(),
+	}
+	requests := srv.Requests()
+	if !reflect.DeepEqual(requests[len(requests)-2:], expected) {
+		t.Fatalf("expected the role to be assigned to the client, got %v", requests)
+	}
+	assignment := srv.Get("/apps/" + clientID + "/roles/" + d.Id())
+	if assignment["role"] != "cr0testAcc" || assignment["resource-set"] != "iamtestAcc" {
+		t.Fatalf("unexpected role assignment: %v", assignment)
+	}
+	if d.Get("status") != statusActive {
+		t.Fatalf("expected status to be read, got %q", d.Get("status"))
+	}
+
+	// a role unassigned outside of Terraform is picked up on read
+	srv.Delete("/apps/" + clientID + "/roles/" + d.Id())
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read role assignment: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected ID to be cleared when the role is unassigned, got %q", d.Id())
+	}
+}
+
+func TestAppOAuthRoleAssignmentValidation(t *testing.T) {
+	r := resourceAppOAuthRoleAssignment()
+	tests := []struct {
+		config  map[string]interface{}
+		message string
+	}{
+		{
+			config: map[string]interface{}{"client_id": "0oa1", "type": "HELP_DESK_ADMIN", "groups": []interface{}{"00g1"}},
+		},
+		{
+			config: map[string]interface{}{"client_id": "0oa1", "type": "APP_ADMIN", "apps": []interface{}{"salesforce"}},
+		},
+		{
+			config: map[string]interface{}{"client_id": "0oa1", "type": "CUSTOM", "role": "cr01", "resource_set": "iam1"},
+		},
+		{
+			config:  map[string]interface{}{"client_id": "0oa1", "type": "CUSTOM"},
+			message: "'role' and 'resource_set' are required when 'type' is 'CUSTOM'",
+		},
+		{
+			config:  map[string]interface{}{"client_id": "0oa1", "type": "SUPER_ADMIN", "role": "cr01", "resource_set": "iam1"},
+			message: "'role' and 'resource_set' can only be set when 'type' is 'CUSTOM'",
+		},
+		{
+			config:  map[string]interface{}{"client_id": "0oa1", "type": "USER_ADMIN", "apps": []interface{}{"salesforce"}},
+			message: "'apps' can only be set when 'type' is 'APP_ADMIN'",
+		},
+		{
+			config:  map[string]interface{}{"client_id": "0oa1", "type": "APP_ADMIN", "groups": []interface{}{"00g1"}},
+			message: "'groups' can only be set when",
+		},
+	}
+	for _, test := range tests {
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(test.config), nil)
+		switch {
+		case test.message == "" && err != nil:
+			t.Errorf("expected %v to be planned, got %v", test.config, err)
+		case test.message != "" && (err == nil || !strings.Contains(err.Error(), test.message)):
+			t.Errorf("expected %v to fail with %q, got %v", test.config, test.message, err)
+		}
+	}
+}
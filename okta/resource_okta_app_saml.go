@@ -50,6 +50,7 @@ func resourceAppSaml() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: appImporter,
 		},
+		CustomizeDiff: validateAppSamlAttributeStatements,
 		// For those familiar with Terraform schemas be sure to check the base application schema and/or
 		// the examples in the documentation
 		Schema: buildAppSchema(map[string]*schema.Schema{
@@ -599,6 +600,33 @@ func resourceAppSamlDelete(ctx context.Context, d *schema.ResourceData, m interf
 	return nil
 }
 
+// validateAppSamlAttributeStatements checks the values of the EXPRESSION
+// attribute statements, the values unknown until apply are skipped.
+func validateAppSamlAttributeStatements(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	statements := d.GetRawConfig().GetAttr("attribute_statements")
+	if !statements.IsKnown() || statements.IsNull() {
+		return nil
+	}
+	for i, statement := range statements.AsValueSlice() {
+		if !statement.IsKnown() || statement.IsNull() {
+			continue
+		}
+		typ, values := statement.GetAttr("type"), statement.GetAttr("values")
+		if !typ.IsKnown() || !typ.IsNull() && typ.AsString() != "EXPRESSION" || !values.IsKnown() || values.IsNull() {
+			continue
+		}
+		for j, value := range values.AsValueSlice() {
+			if !value.IsKnown() || value.IsNull() {
+				continue
+			}
+			if err := oktaExpressionError(fmt.Sprintf("attribute_statements.%d.values.%d", i, j), value.AsString()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func buildSamlApp(d *schema.ResourceData) (*okta.SamlApplication, error) {
 	// Abstracts away name and SignOnMode which are constant for this app type.
 	app := okta.NewSamlApplication()
@@ -8,7 +8,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/okta/okta-sdk-golang/v2/okta"
@@ -16,10 +15,10 @@ import (
 
 // Ensure conditional require logic causes this plan to fail
 func TestAccAppSaml_conditionalRequire(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := buildTestSamlConfigMissingFields(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSaml, createDoesAppExist(okta.NewSamlApplication())),
@@ -34,10 +33,10 @@ func TestAccAppSaml_conditionalRequire(t *testing.T) {
 
 // Ensure conditional require logic causes this plan to fail
 func TestAccAppSaml_invalidURL(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := buildTestSamlConfigInvalidURL(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSaml, createDoesAppExist(okta.NewSamlApplication())),
@@ -51,7 +50,7 @@ func TestAccAppSaml_invalidURL(t *testing.T) {
 }
 
 func TestAccAppSaml_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSaml)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	allFields := mgr.GetFixtures("updated.tf", ri, t)
@@ -59,7 +58,7 @@ func TestAccAppSaml_crud(t *testing.T) {
 	importSAML11Config := mgr.GetFixtures("import_saml_1_1.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSaml)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSaml, createDoesAppExist(okta.NewSamlApplication())),
@@ -152,12 +151,12 @@ func TestAccAppSaml_crud(t *testing.T) {
 }
 
 func TestAccAppSaml_preconfigured(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSaml)
 	preconfigured := mgr.GetFixtures("preconfigured.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSaml)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSaml, createDoesAppExist(okta.NewSamlApplication())),
@@ -232,13 +231,13 @@ func areJSONStringsEqual(a, b string) bool {
 
 // Add and remove groups/users
 func TestAccAppSaml_userGroups(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSaml)
 	config := mgr.GetFixtures("user_groups.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("user_groups_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSaml)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSaml, createDoesAppExist(okta.NewSamlApplication())),
@@ -271,12 +270,12 @@ func TestAccAppSaml_userGroups(t *testing.T) {
 }
 
 func TestAccAppSaml_inlineHook(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSaml)
 	config := mgr.GetFixtures("basic_inline_hook.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSaml)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSaml, createDoesAppExist(okta.NewSamlApplication())),
@@ -302,13 +301,13 @@ func TestAccAppSaml_federationBroker(t *testing.T) {
 	//       SEE https://help.okta.com/en/prod/Content/Topics/Apps/apps-fbm-enable.htm
 	t.Skip("This is an 'Early Access Feature' and needs to be enabled by Okta, skipping this test as it fails when this feature is not available")
 
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSaml)
 	config := mgr.GetFixtures("federation_broker_off.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("federation_broker_on.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSaml)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appOAuth, createDoesAppExist(okta.NewOpenIdConnectApplication())),
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/sdk"
 )
 
 func resourceAppSignOnPolicyRule() *schema.Resource {
@@ -110,6 +111,13 @@ func resourceAppSignOnPolicyRule() *schema.Resource {
 				RequiredWith: []string{"device_is_registered"},
 				Description:  "If the device is managed. A device is managed if it's managed by a device management system. When managed is passed, registered must also be included and must be set to true.",
 			},
+			"device_assurances_included": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				RequiredWith: []string{"device_is_registered"},
+				Description:  "List of device assurance policy IDs to include, the device must meet one of them. Registered must also be set to true.",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+			},
 			"platform_include": {
 				Type:     schema.TypeSet,
 				Elem:     platformIncludeResource,
@@ -242,6 +250,9 @@ func resourceAppSignOnPolicyRuleRead(ctx context.Context, d *schema.ResourceData
 		if rule.Conditions.Device != nil {
 			_ = d.Set("device_is_managed", rule.Conditions.Device.Managed)
 			_ = d.Set("device_is_registered", rule.Conditions.Device.Registered)
+			if rule.Conditions.Device.Assurance != nil {
+				m["device_assurances_included"] = convertStringSliceToSetNullable(rule.Conditions.Device.Assurance.Include)
+			}
 		}
 		if rule.Conditions.People != nil {
 			if rule.Conditions.People.Users != nil {
@@ -293,8 +304,8 @@ func resourceAppSignOnPolicyRuleDelete(ctx context.Context, d *schema.ResourceDa
 	return nil
 }
 
-func buildAppSignOnPolicyRule(d *schema.ResourceData) okta.AccessPolicyRule {
-	rule := okta.AccessPolicyRule{
+func buildAppSignOnPolicyRule(d *schema.ResourceData) sdk.AccessPolicyRule {
+	rule := sdk.AccessPolicyRule{
 		Actions: &okta.AccessPolicyRuleActions{
 			AppSignOn: &okta.AccessPolicyRuleApplicationSignOn{
 				Access: d.Get("access").(string),
@@ -325,20 +336,29 @@ func buildAppSignOnPolicyRule(d *schema.ResourceData) okta.AccessPolicyRule {
 	if d.Get("name") == "Catch-all Rule" {
 		return rule
 	}
-	rule.Conditions = &okta.AccessPolicyRuleConditions{
-		Network: buildPolicyNetworkCondition(d),
-		Platform: &okta.PlatformPolicyRuleCondition{
-			Include: buildAccessPolicyPlatformInclude(d),
-		},
-		ElCondition: &okta.AccessPolicyRuleCustomCondition{
-			Condition: d.Get("custom_expression").(string),
+	rule.Conditions = &sdk.AccessPolicyRuleConditions{
+		AccessPolicyRuleConditions: okta.AccessPolicyRuleConditions{
+			Network: buildPolicyNetworkCondition(d),
+			Platform: &okta.PlatformPolicyRuleCondition{
+				Include: buildAccessPolicyPlatformInclude(d),
+			},
+			ElCondition: &okta.AccessPolicyRuleCustomCondition{
+				Condition: d.Get("custom_expression").(string),
+			},
 		},
 	}
 	isRegistered, ok := d.GetOk("device_is_registered")
 	if ok && isRegistered.(bool) {
-		rule.Conditions.Device = &okta.DeviceAccessPolicyRuleCondition{
-			Managed:    boolPtr(d.Get("device_is_managed").(bool)),
-			Registered: boolPtr(isRegistered.(bool)),
+		rule.Conditions.Device = &sdk.DeviceAccessPolicyRuleCondition{
+			DeviceAccessPolicyRuleCondition: okta.DeviceAccessPolicyRuleCondition{
+				Managed:    boolPtr(d.Get("device_is_managed").(bool)),
+				Registered: boolPtr(isRegistered.(bool)),
+			},
+		}
+		if assurances, ok := d.GetOk("device_assurances_included"); ok {
+			rule.Conditions.Device.Assurance = &sdk.DeviceAssurancePolicyRuleCondition{
+				Include: convertInterfaceToStringSetNullable(assurances),
+			}
 		}
 	}
 	usersExcluded, usersExcludedOk := d.GetOk("users_excluded")
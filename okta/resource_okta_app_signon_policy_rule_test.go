@@ -4,21 +4,22 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOktaAppSignOnPolicyRule(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", appSignOnPolicyRule)
 	mgr := newFixtureManager(appSignOnPolicyRule)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("basic_updated.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      appSignOnPolicyRuleExists,
@@ -73,6 +74,62 @@ func TestAccOktaAppSignOnPolicyRule(t *testing.T) {
 	})
 }
 
+func TestAccOktaAppSignOnPolicyRule_deviceAssurance(t *testing.T) {
+	ri := vcrRandInt(t)
+	resourceName := fmt.Sprintf("%s.test", appSignOnPolicyRule)
+	mgr := newFixtureManager(appSignOnPolicyRule)
+	config := mgr.GetFixtures("device_assurance.tf", ri, t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             appSignOnPolicyRuleExists,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "device_is_registered", "true"),
+					resource.TestCheckResourceAttr(resourceName, "device_assurances_included.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "device_assurances_included.*", fmt.Sprintf("%s.test", deviceAssurancePolicy), "id"),
+				),
+			},
+		},
+	})
+}
+
+// TestAppSignOnPolicyRuleDeviceAssurances checks the device assurance policies
+// are sent and read in the device condition of the rule, with the fake server.
+func TestAppSignOnPolicyRuleDeviceAssurances(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	policyID := srv.Put("/policies", map[string]interface{}{"type": "ACCESS_POLICY", "name": "app"})
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	r := resourceAppSignOnPolicyRule()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id":                  policyID,
+		"name":                       "rule",
+		"device_is_registered":       true,
+		"device_assurances_included": []interface{}{"dae1", "dae2"},
+	})
+	if diags := r.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("failed to create app sign-on policy rule: %v", diags)
+	}
+	device, _ := srv.Get("/policies/" + policyID + "/rules/" + d.Id())["conditions"].(map[string]interface{})["device"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"registered": true,
+		"managed":    false,
+		"assurance":  map[string]interface{}{"include": []interface{}{"dae1", "dae2"}},
+	}
+	if !reflect.DeepEqual(device, expected) {
+		t.Fatalf("expected the device condition %v, got %v", expected, device)
+	}
+	if n := d.Get("device_assurances_included").(*schema.Set).Len(); n != 2 {
+		t.Errorf("expected the device assurance policies to be read, got %d", n)
+	}
+}
+
 func appSignOnPolicyRuleExists(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != appSignOnPolicyRule {
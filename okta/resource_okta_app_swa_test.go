@@ -4,20 +4,19 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 )
 
 // Test creation of a simple AWS SWA app. The preconfigured apps are created by name.
 func TestAccAppSwaApplication_preconfig(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSwa)
 	config := mgr.GetFixtures("preconfig.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("preconfig_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSwa)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSwa, createDoesAppExist(okta.NewSwaApplication())),
@@ -46,13 +45,13 @@ func TestAccAppSwaApplication_preconfig(t *testing.T) {
 
 // Test creation of a custom SAML app.
 func TestAccAppSwaApplication_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(appSwa)
 	config := mgr.GetFixtures("custom.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("custom_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", appSwa)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(appSwa, createDoesAppExist(okta.NewSwaApplication())),
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceAppUserSchemaProperty() *schema.Resource {
@@ -131,7 +132,7 @@ func setAppUserSchemaProperty(ctx context.Context, d *schema.ResourceData, m int
 			}
 			return err
 		}
-		us, resp, err := getOktaClientFromMetadata(m).UserSchema.GetApplicationUserSchema(ctx, d.Get("app_id").(string))
+		us, resp, err := getOktaClientFromMetadata(m).UserSchema.GetApplicationUserSchema(transport.SkipCache(ctx), d.Get("app_id").(string))
 		if err := suppressErrorOn404(resp, err); err != nil {
 			return err
 		}
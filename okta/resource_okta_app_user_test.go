@@ -6,20 +6,19 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOktaAppUser_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", appUser)
 	mgr := newFixtureManager(appUser)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	update := mgr.GetFixtures("update.tf", ri, t)
 	basicProfile := mgr.GetFixtures("basic_profile.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkAppUserDestroy,
@@ -79,7 +78,7 @@ func TestAccOktaAppUser_crud(t *testing.T) {
 }
 
 func TestAccOktaAppUser_retain(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", appUser)
 	appName := fmt.Sprintf("%s.test", appOAuth)
 	userName := fmt.Sprintf("%s.test", user)
@@ -87,7 +86,7 @@ func TestAccOktaAppUser_retain(t *testing.T) {
 	retain := mgr.GetFixtures("retain.tf", ri, t)
 	retainDestroy := mgr.GetFixtures("retain_destroy.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkAppUserDestroy,
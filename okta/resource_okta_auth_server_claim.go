@@ -15,6 +15,12 @@ func resourceAuthServerClaim() *schema.Resource {
 		UpdateContext: resourceAuthServerClaimUpdate,
 		DeleteContext: resourceAuthServerClaimDelete,
 		Importer:      createNestedResourceImporter([]string{"auth_server_id", "id"}),
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if d.Get("value_type").(string) != "EXPRESSION" || !d.NewValueKnown("value") {
+				return nil
+			}
+			return oktaExpressionError("value", d.Get("value").(string))
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -19,14 +18,14 @@ func authServerExists(id string) (bool, error) {
 }
 
 func TestAccOktaAuthServer_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.sun_also_rises", authServer)
 	name := buildResourceName(ri)
 	mgr := newFixtureManager(authServer)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("basic_updated.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(authServer, authServerExists),
@@ -56,7 +55,7 @@ func TestAccOktaAuthServer_crud(t *testing.T) {
 }
 
 func TestAccOktaAuthServer_fullStack(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	name := buildResourceName(ri)
 	resourceName := fmt.Sprintf("%s.test", authServer)
 	claimName := fmt.Sprintf("%s.test", authServerClaim)
@@ -67,7 +66,7 @@ func TestAccOktaAuthServer_fullStack(t *testing.T) {
 	config := mgr.GetFixtures("full_stack.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("full_stack_with_client.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(authServer, authServerExists),
@@ -108,14 +107,14 @@ func TestAccOktaAuthServer_fullStack(t *testing.T) {
 }
 
 func TestAccOktaAuthServer_gh299(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	name := buildResourceName(ri)
 	resourceName := fmt.Sprintf("%s.test", authServer)
 	resource2Name := fmt.Sprintf("%s.test1", authServer)
 	mgr := newFixtureManager(authServer)
 	config := mgr.GetFixtures("dependency.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(authServer, authServerExists),
@@ -0,0 +1,395 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+var osVersionRegex = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+// deviceAssurancePlatforms are the names of the blocks of the platforms and the
+// platforms they are for.
+var deviceAssurancePlatforms = map[string]string{
+	"android": sdk.DeviceAssurancePlatformAndroid,
+	"ios":     sdk.DeviceAssurancePlatformIOS,
+	"macos":   sdk.DeviceAssurancePlatformMacOS,
+	"windows": sdk.DeviceAssurancePlatformWindows,
+}
+
+type deviceAssurancePolicyResourceType struct{}
+
+type deviceAssurancePolicyResource struct {
+	provider *frameworkProvider
+}
+
+type deviceAssurancePolicyModel struct {
+	ID       types.String                  `tfsdk:"id"`
+	Name     types.String                  `tfsdk:"name"`
+	Platform types.String                  `tfsdk:"platform"`
+	Android  []deviceAssuranceAndroidModel `tfsdk:"android"`
+	IOS      []deviceAssuranceIOSModel     `tfsdk:"ios"`
+	MacOS    []deviceAssuranceDesktopModel `tfsdk:"macos"`
+	Windows  []deviceAssuranceDesktopModel `tfsdk:"windows"`
+}
+
+type deviceAssuranceAndroidModel struct {
+	OSVersion             types.String `tfsdk:"os_version"`
+	DiskEncryptionType    types.Set    `tfsdk:"disk_encryption_type"`
+	ScreenLockType        types.Set    `tfsdk:"screen_lock_type"`
+	SecureHardwarePresent types.Bool   `tfsdk:"secure_hardware_present"`
+	Jailbreak             types.Bool   `tfsdk:"jailbreak"`
+}
+
+type deviceAssuranceIOSModel struct {
+	OSVersion      types.String `tfsdk:"os_version"`
+	ScreenLockType types.Set    `tfsdk:"screen_lock_type"`
+	Jailbreak      types.Bool   `tfsdk:"jailbreak"`
+}
+
+// deviceAssuranceDesktopModel is the block of the macOS and of the Windows
+// device assurance policies, which have the same requirements.
+type deviceAssuranceDesktopModel struct {
+	OSVersion             types.String `tfsdk:"os_version"`
+	DiskEncryptionType    types.Set    `tfsdk:"disk_encryption_type"`
+	ScreenLockType        types.Set    `tfsdk:"screen_lock_type"`
+	SecureHardwarePresent types.Bool   `tfsdk:"secure_hardware_present"`
+}
+
+func (deviceAssurancePolicyResourceType) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Manages a device assurance policy, the OS version, disk encryption, screen lock and jailbreak requirements of the devices of a platform. Exactly one of the android, ios, macos and windows blocks must be set.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.StringType,
+				Computed:      true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.UseStateForUnknown()},
+				Description:   "ID of the device assurance policy",
+			},
+			"name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Name of the device assurance policy",
+			},
+			"platform": {
+				Type:        types.StringType,
+				Computed:    true,
+				Description: "Platform of the device assurance policy: ANDROID, IOS, MACOS or WINDOWS, of its block",
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"android": deviceAssurancePlatformBlock(sdk.DeviceAssurancePlatformAndroid, []string{"FULL", "USER"}, true, true),
+			"ios":     deviceAssurancePlatformBlock(sdk.DeviceAssurancePlatformIOS, nil, false, true),
+			"macos":   deviceAssurancePlatformBlock(sdk.DeviceAssurancePlatformMacOS, []string{"ALL_INTERNAL_VOLUMES"}, true, false),
+			"windows": deviceAssurancePlatformBlock(sdk.DeviceAssurancePlatformWindows, []string{"ALL_INTERNAL_VOLUMES"}, true, false),
+		},
+	}, nil
+}
+
+// deviceAssurancePlatformBlock is the block of the requirements of a platform,
+// the disk encryption types are the ones of the platform, if it has any.
+func deviceAssurancePlatformBlock(platform string, diskEncryptionTypes []string, secureHardware, jailbreak bool) tfsdk.Block {
+	attributes := map[string]tfsdk.Attribute{
+		"os_version": {
+			Type:        types.StringType,
+			Optional:    true,
+			Validators:  []tfsdk.AttributeValidator{stringMatchesRegex(osVersionRegex, "OS version, e.g. 12.4.5")},
+			Description: "Minimum version of the OS, e.g. 12.4.5",
+		},
+		"screen_lock_type": {
+			Type:        types.SetType{ElemType: types.StringType},
+			Optional:    true,
+			Validators:  []tfsdk.AttributeValidator{stringSetOneOf("PASSCODE", "BIOMETRIC")},
+			Description: "Screen lock types the device must have one of: PASSCODE or BIOMETRIC",
+		},
+	}
+	if len(diskEncryptionTypes) > 0 {
+		attributes["disk_encryption_type"] = tfsdk.Attribute{
+			Type:        types.SetType{ElemType: types.StringType},
+			Optional:    true,
+			Validators:  []tfsdk.AttributeValidator{stringSetOneOf(diskEncryptionTypes...)},
+			Description: "Disk encryption types the device must have one of: " + strings.Join(diskEncryptionTypes, ", "),
+		}
+	}
+	if secureHardware {
+		attributes["secure_hardware_present"] = tfsdk.Attribute{
+			Type:        types.BoolType,
+			Optional:    true,
+			Description: "Whether the device must have a secure hardware, e.g. a TPM",
+		}
+	}
+	if jailbreak {
+		attributes["jailbreak"] = tfsdk.Attribute{
+			Type:        types.BoolType,
+			Optional:    true,
+			Description: "Whether the device may be jailbroken or rooted, false requires a device which isn't",
+		}
+	}
+	return tfsdk.Block{
+		NestingMode: tfsdk.BlockNestingModeList,
+		MaxItems:    1,
+		Description: fmt.Sprintf("Requirements of the %s devices", platform),
+		Attributes:  attributes,
+	}
+}
+
+func (deviceAssurancePolicyResourceType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return &deviceAssurancePolicyResource{provider: p.(*frameworkProvider)}, nil
+}
+
+// ValidateConfig checks exactly one platform block is set.
+func (r *deviceAssurancePolicyResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var config deviceAssurancePolicyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var blocks int
+	for _, n := range []int{len(config.Android), len(config.IOS), len(config.MacOS), len(config.Windows)} {
+		blocks += n
+	}
+	if blocks != 1 {
+		resp.Diagnostics.AddError("Invalid platform",
+			fmt.Sprintf("exactly one of the android, ios, macos and windows blocks must be set, got %d", blocks))
+	}
+}
+
+// ModifyPlan sets the platform of the block, the device assurance policy is
+// replaced when it changes as the platform can't be updated.
+func (r *deviceAssurancePolicyResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	checkFrameworkOrgEngine(deviceAssurancePolicy, r.provider.config, &resp.Diagnostics)
+	var plan deviceAssurancePolicyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	platform := deviceAssurancePlatform(plan)
+	if platform == "" {
+		return
+	}
+	plan.Platform = types.String{Value: platform}
+	if !req.State.Raw.IsNull() {
+		var state deviceAssurancePolicyModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Platform.Value != platform {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("platform"))
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *deviceAssurancePolicyResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var plan deviceAssurancePolicyModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, deviceAssurancePolicy, "create")
+	defer func() { endFrameworkSpan(span, plan.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.provider.config.logger.Info("creating device assurance policy", "name", plan.Name.Value)
+	created, _, err := r.provider.config.supplementClient.CreateDeviceAssurance(ctx, buildDeviceAssurance(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create device assurance policy", err.Error())
+		return
+	}
+	plan.ID = types.String{Value: created.ID}
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *deviceAssurancePolicyResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state deviceAssurancePolicyModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, deviceAssurancePolicy, "read")
+	defer func() { endFrameworkSpan(span, state.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.read(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.Null {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *deviceAssurancePolicyResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan, state deviceAssurancePolicyModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, deviceAssurancePolicy, "update")
+	defer func() { endFrameworkSpan(span, state.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.provider.config.logger.Info("updating device assurance policy", "id", state.ID.Value, "name", plan.Name.Value)
+	_, _, err := r.provider.config.supplementClient.UpdateDeviceAssurance(ctx, state.ID.Value, buildDeviceAssurance(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update device assurance policy", err.Error())
+		return
+	}
+	plan.ID = state.ID
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *deviceAssurancePolicyResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state deviceAssurancePolicyModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, deviceAssurancePolicy, "delete")
+	defer func() { endFrameworkSpan(span, state.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.provider.config.logger.Info("deleting device assurance policy", "id", state.ID.Value, "name", state.Name.Value)
+	apiResp, err := r.provider.config.supplementClient.DeleteDeviceAssurance(ctx, state.ID.Value)
+	if err := suppressErrorOn404(apiResp, err); err != nil {
+		resp.Diagnostics.AddError("Failed to delete device assurance policy", err.Error())
+	}
+}
+
+func (r *deviceAssurancePolicyResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// read sets the model to the device assurance policy of its ID, the ID is set
+// to null when the device assurance policy doesn't exist.
+func (r *deviceAssurancePolicyResource) read(ctx context.Context, model *deviceAssurancePolicyModel, diags *diag.Diagnostics) {
+	deviceAssurance, resp, err := r.provider.config.supplementClient.GetDeviceAssurance(ctx, model.ID.Value)
+	if err := suppressErrorOn404(resp, err); err != nil {
+		diags.AddError("Failed to get device assurance policy", err.Error())
+		return
+	}
+	if deviceAssurance == nil {
+		model.ID = types.String{Null: true}
+		return
+	}
+	model.Name = types.String{Value: deviceAssurance.Name}
+	model.Platform = types.String{Value: deviceAssurance.Platform}
+	model.Android = []deviceAssuranceAndroidModel{}
+	model.IOS = []deviceAssuranceIOSModel{}
+	model.MacOS = []deviceAssuranceDesktopModel{}
+	model.Windows = []deviceAssuranceDesktopModel{}
+
+	var osVersion types.String
+	if deviceAssurance.OSVersion != nil {
+		osVersion = stringValue(deviceAssurance.OSVersion.Minimum)
+	} else {
+		osVersion = types.String{Null: true}
+	}
+	diskEncryptionType := stringSetValue(nil)
+	if deviceAssurance.DiskEncryptionType != nil {
+		diskEncryptionType = stringSetValue(deviceAssurance.DiskEncryptionType.Include)
+	}
+	screenLockType := stringSetValue(nil)
+	if deviceAssurance.ScreenLockType != nil {
+		screenLockType = stringSetValue(deviceAssurance.ScreenLockType.Include)
+	}
+	switch deviceAssurance.Platform {
+	case sdk.DeviceAssurancePlatformAndroid:
+		model.Android = []deviceAssuranceAndroidModel{{
+			OSVersion:             osVersion,
+			DiskEncryptionType:    diskEncryptionType,
+			ScreenLockType:        screenLockType,
+			SecureHardwarePresent: boolValue(deviceAssurance.SecureHardwarePresent),
+			Jailbreak:             boolValue(deviceAssurance.Jailbreak),
+		}}
+	case sdk.DeviceAssurancePlatformIOS:
+		model.IOS = []deviceAssuranceIOSModel{{
+			OSVersion:      osVersion,
+			ScreenLockType: screenLockType,
+			Jailbreak:      boolValue(deviceAssurance.Jailbreak),
+		}}
+	case sdk.DeviceAssurancePlatformMacOS, sdk.DeviceAssurancePlatformWindows:
+		desktop := []deviceAssuranceDesktopModel{{
+			OSVersion:             osVersion,
+			DiskEncryptionType:    diskEncryptionType,
+			ScreenLockType:        screenLockType,
+			SecureHardwarePresent: boolValue(deviceAssurance.SecureHardwarePresent),
+		}}
+		if deviceAssurance.Platform == sdk.DeviceAssurancePlatformMacOS {
+			model.MacOS = desktop
+		} else {
+			model.Windows = desktop
+		}
+	}
+}
+
+// deviceAssurancePlatform is the platform of the block of the model, empty when
+// there isn't exactly one.
+func deviceAssurancePlatform(model deviceAssurancePolicyModel) string {
+	var platforms []string
+	for name, n := range map[string]int{
+		"android": len(model.Android),
+		"ios":     len(model.IOS),
+		"macos":   len(model.MacOS),
+		"windows": len(model.Windows),
+	} {
+		if n > 0 {
+			platforms = append(platforms, deviceAssurancePlatforms[name])
+		}
+	}
+	if len(platforms) != 1 {
+		return ""
+	}
+	return platforms[0]
+}
+
+func buildDeviceAssurance(model deviceAssurancePolicyModel) sdk.DeviceAssurance {
+	deviceAssurance := sdk.DeviceAssurance{
+		Name:     model.Name.Value,
+		Platform: deviceAssurancePlatform(model),
+	}
+	var (
+		osVersion          types.String
+		diskEncryptionType types.Set
+		screenLockType     types.Set
+	)
+	switch {
+	case len(model.Android) == 1:
+		android := model.Android[0]
+		osVersion, diskEncryptionType, screenLockType = android.OSVersion, android.DiskEncryptionType, android.ScreenLockType
+		deviceAssurance.SecureHardwarePresent = boolPointer(android.SecureHardwarePresent)
+		deviceAssurance.Jailbreak = boolPointer(android.Jailbreak)
+	case len(model.IOS) == 1:
+		ios := model.IOS[0]
+		osVersion, screenLockType = ios.OSVersion, ios.ScreenLockType
+		deviceAssurance.Jailbreak = boolPointer(ios.Jailbreak)
+	case len(model.MacOS) == 1 || len(model.Windows) == 1:
+		desktop := append(append([]deviceAssuranceDesktopModel{}, model.MacOS...), model.Windows...)[0]
+		osVersion, diskEncryptionType, screenLockType = desktop.OSVersion, desktop.DiskEncryptionType, desktop.ScreenLockType
+		deviceAssurance.SecureHardwarePresent = boolPointer(desktop.SecureHardwarePresent)
+	}
+	if osVersion.Value != "" {
+		deviceAssurance.OSVersion = &sdk.DeviceAssuranceOSVersion{Minimum: osVersion.Value}
+	}
+	if values := stringSetElems(diskEncryptionType); len(values) > 0 {
+		deviceAssurance.DiskEncryptionType = &sdk.DeviceAssuranceDiskEncryptionType{Include: values}
+	}
+	if values := stringSetElems(screenLockType); len(values) > 0 {
+		deviceAssurance.ScreenLockType = &sdk.DeviceAssuranceScreenLockType{Include: values}
+	}
+	return deviceAssurance
+}
@@ -0,0 +1,206 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestAccOktaDeviceAssurancePolicy(t *testing.T) {
+	ri := vcrRandInt(t)
+	mgr := newFixtureManager(deviceAssurancePolicy)
+	config := mgr.GetFixtures("basic.tf", ri, t)
+	updated := mgr.GetFixtures("basic_updated.tf", ri, t)
+	macos := mgr.GetFixtures("macos.tf", ri, t)
+	resourceName := fmt.Sprintf("%s.test", deviceAssurancePolicy)
+	oktaResourceTest(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             createCheckResourceDestroy(deviceAssurancePolicy, doesDeviceAssurancePolicyExist),
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(ri)),
+						resource.TestCheckResourceAttr(resourceName, "platform", sdk.DeviceAssurancePlatformAndroid),
+						resource.TestCheckResourceAttr(resourceName, "android.0.os_version", "12"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.disk_encryption_type.#", "2"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.screen_lock_type.#", "1"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.jailbreak", "false"),
+					),
+				},
+				{
+					Config: updated,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(ri)+"_updated"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.os_version", "13"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.disk_encryption_type.#", "1"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.screen_lock_type.#", "2"),
+						resource.TestCheckResourceAttr(resourceName, "android.0.secure_hardware_present", "true"),
+					),
+				},
+				{
+					ResourceName:      resourceName,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+				{
+					Config: macos,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "platform", sdk.DeviceAssurancePlatformMacOS),
+						resource.TestCheckResourceAttr(resourceName, "android.#", "0"),
+						resource.TestCheckResourceAttr(resourceName, "macos.0.os_version", "12.4.5"),
+					),
+				},
+			},
+		})
+}
+
+func doesDeviceAssurancePolicyExist(id string) (bool, error) {
+	_, response, err := getSupplementFromMetadata(testAccProvider.Meta()).GetDeviceAssurance(context.Background(), id)
+	return doesResourceExist(response, err)
+}
+
+func testAndroidDeviceAssurancePolicy(name, osVersion string) deviceAssurancePolicyModel {
+	return deviceAssurancePolicyModel{
+		ID:       types.String{Unknown: true},
+		Name:     types.String{Value: name},
+		Platform: types.String{Unknown: true},
+		Android: []deviceAssuranceAndroidModel{{
+			OSVersion:             types.String{Value: osVersion},
+			DiskEncryptionType:    stringSetValue([]string{"FULL"}),
+			ScreenLockType:        stringSetValue([]string{"PASSCODE", "BIOMETRIC"}),
+			SecureHardwarePresent: types.Bool{Null: true},
+			Jailbreak:             types.Bool{Value: false},
+		}},
+		IOS:     []deviceAssuranceIOSModel{},
+		MacOS:   []deviceAssuranceDesktopModel{},
+		Windows: []deviceAssuranceDesktopModel{},
+	}
+}
+
+// TestDeviceAssurancePolicyLifecycle runs the create, update and delete of an
+// Android device assurance policy against the fake server, and the plan of a
+// change of its platform.
+func TestDeviceAssurancePolicyLifecycle(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	ctx := context.Background()
+	s, _ := deviceAssurancePolicyResourceType{}.GetSchema(ctx)
+	r := &deviceAssurancePolicyResource{provider: &frameworkProvider{config: config}}
+
+	model := testAndroidDeviceAssurancePolicy("android", "12")
+	model.Platform = types.String{Value: sdk.DeviceAssurancePlatformAndroid}
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.TerraformType(ctx), nil)}}
+	r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("failed to create device assurance policy: %v", createResp.Diagnostics)
+	}
+	var state deviceAssurancePolicyModel
+	createResp.State.Get(ctx, &state)
+	item := srv.Get("/device-assurances/" + state.ID.Value)
+	expected := map[string]interface{}{"minimum": "12"}
+	if item["platform"] != sdk.DeviceAssurancePlatformAndroid || !reflect.DeepEqual(item["osVersion"], expected) || item["jailbreak"] != false {
+		t.Fatalf("unexpected device assurance policy %v", item)
+	}
+	if _, ok := item["secureHardwarePresent"]; ok {
+		t.Errorf("expected secureHardwarePresent to be left out, got %v", item)
+	}
+	if state.Platform.Value != sdk.DeviceAssurancePlatformAndroid || len(state.Android) != 1 || len(stringSetElems(state.Android[0].ScreenLockType)) != 2 {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+
+	model = state
+	model.Android[0].OSVersion = types.String{Value: "13"}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	updateResp := tfsdk.UpdateResourceResponse{State: createResp.State}
+	r.Update(ctx, tfsdk.UpdateResourceRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("failed to update device assurance policy: %v", updateResp.Diagnostics)
+	}
+	if item := srv.Get("/device-assurances/" + state.ID.Value); !reflect.DeepEqual(item["osVersion"], map[string]interface{}{"minimum": "13"}) {
+		t.Fatalf("expected the OS version to be updated, got %v", item)
+	}
+
+	// the platform of the block changes from ANDROID to IOS
+	model.Android = []deviceAssuranceAndroidModel{}
+	model.IOS = []deviceAssuranceIOSModel{{
+		OSVersion:      types.String{Value: "15.4"},
+		ScreenLockType: stringSetValue(nil),
+		Jailbreak:      types.Bool{Null: true},
+	}}
+	model.Platform = types.String{Unknown: true}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	modifyResp := tfsdk.ModifyResourcePlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, tfsdk.ModifyResourcePlanRequest{Plan: plan, State: updateResp.State}, &modifyResp)
+	if modifyResp.Diagnostics.HasError() {
+		t.Fatalf("failed to plan device assurance policy: %v", modifyResp.Diagnostics)
+	}
+	if !reflect.DeepEqual(modifyResp.RequiresReplace, path.Paths{path.Root("platform")}) {
+		t.Errorf("expected the change of platform to replace the device assurance policy, got %v", modifyResp.RequiresReplace)
+	}
+	var planned deviceAssurancePolicyModel
+	modifyResp.Plan.Get(ctx, &planned)
+	if planned.Platform.Value != sdk.DeviceAssurancePlatformIOS {
+		t.Errorf("expected the platform to be planned, got %v", planned.Platform)
+	}
+
+	r.Delete(ctx, tfsdk.DeleteResourceRequest{State: updateResp.State}, &tfsdk.DeleteResourceResponse{})
+	if srv.Get("/device-assurances/"+state.ID.Value) != nil {
+		t.Fatal("expected the device assurance policy to be deleted")
+	}
+}
+
+func TestDeviceAssurancePolicyPlan(t *testing.T) {
+	ctx := context.Background()
+	s, _ := deviceAssurancePolicyResourceType{}.GetSchema(ctx)
+	model := testAndroidDeviceAssurancePolicy("android", "12")
+	model.MacOS = []deviceAssuranceDesktopModel{{
+		OSVersion:             types.String{Value: "12.4.5"},
+		DiskEncryptionType:    stringSetValue(nil),
+		ScreenLockType:        stringSetValue(nil),
+		SecureHardwarePresent: types.Bool{Null: true},
+	}}
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	r := &deviceAssurancePolicyResource{provider: &frameworkProvider{}}
+	var validateResp tfsdk.ValidateResourceConfigResponse
+	r.ValidateConfig(ctx, tfsdk.ValidateResourceConfigRequest{Config: tfsdk.Config{Schema: s, Raw: plan.Raw}}, &validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Error("expected an error when two platform blocks are set")
+	}
+
+	model.MacOS = []deviceAssuranceDesktopModel{}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	r.provider.config = &Config{classicOrg: true, logger: hclog.NewNullLogger()}
+	modifyResp := tfsdk.ModifyResourcePlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, tfsdk.ModifyResourcePlanRequest{Plan: plan, State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.TerraformType(ctx), nil)}}, &modifyResp)
+	if !modifyResp.Diagnostics.HasError() {
+		t.Error("expected the plan to fail against a Classic org")
+	}
+}
@@ -16,6 +16,9 @@ func resourceDomainVerification() *schema.Resource {
 		ReadContext:   resourceDomainVerificationRead,
 		DeleteContext: resourceDomainVerificationDelete,
 		Importer:      nil,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+		},
 		Schema: map[string]*schema.Schema{
 			"domain_id": {
 				Type:        schema.TypeString,
@@ -28,9 +31,12 @@ func resourceDomainVerification() *schema.Resource {
 }
 
 func resourceDomainVerificationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the DNS records of the domain can take a while to propagate, the
+	// verification is retried until the create timeout
 	bOff := backoff.NewExponentialBackOff()
-	bOff.MaxElapsedTime = time.Second * 30
+	bOff.MaxElapsedTime = d.Timeout(schema.TimeoutCreate)
 	bOff.InitialInterval = time.Second
+	bc := backoff.WithContext(bOff, ctx)
 	err := backoff.Retry(func() error {
 		domain, _, err := getOktaClientFromMetadata(m).Domain.VerifyDomain(ctx, d.Get("domain_id").(string))
 		if err != nil {
@@ -40,7 +46,7 @@ func resourceDomainVerificationCreate(ctx context.Context, d *schema.ResourceDat
 			return fmt.Errorf("failed to verify domain after several attempts, current validation status: %s", domain.ValidationStatus)
 		}
 		return nil
-	}, bOff)
+	}, bc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
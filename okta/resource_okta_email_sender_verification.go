@@ -2,9 +2,14 @@ package okta
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
@@ -14,6 +19,9 @@ func resourceEmailSenderVerification() *schema.Resource {
 		ReadContext:   resourceEmailSenderVerificationRead,
 		DeleteContext: resourceEmailSenderVerificationDelete,
 		Importer:      nil,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"sender_id": {
 				Type:        schema.TypeString,
@@ -37,7 +45,7 @@ func resourceEmailSenderVerificationCreate(ctx context.Context, d *schema.Resour
 		PendingID:               sender.ID,
 		PendingDNSValidation:    sender.DNSValidation,
 	}
-	_, err = getSupplementFromMetadata(m).ValidateEmailSender(ctx, sender.ID, esv)
+	err = verifyEmailSender(ctx, getSupplementFromMetadata(m), sender.ID, esv, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return diag.Errorf("failed to verify custom email sender: %v", err)
 	}
@@ -45,6 +53,37 @@ func resourceEmailSenderVerificationCreate(ctx context.Context, d *schema.Resour
 	return nil
 }
 
+// emailSenderDNSPendingErrorCode is the error code of Okta's response to the
+// validation of a sender whose DNS records can't be found yet.
+const emailSenderDNSPendingErrorCode = "E0000175"
+
+// verifyEmailSender validates the sender. The DNS records of the sender can
+// take a while to propagate, the validation is retried until the timeout while
+// Okta can't find them, any other client error is final.
+func verifyEmailSender(ctx context.Context, supplement *sdk.APISupplement, id string, esv sdk.EmailSenderValidation, timeout time.Duration) error {
+	bOff := backoff.NewExponentialBackOff()
+	bOff.MaxElapsedTime = timeout
+	bOff.InitialInterval = time.Second
+	bc := backoff.WithContext(bOff, ctx)
+	return backoff.Retry(func() error {
+		resp, err := supplement.ValidateEmailSender(ctx, id, esv)
+		if err == nil || isEmailSenderDNSPending(err) {
+			return err
+		}
+		if resp != nil && resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, bc)
+}
+
+// isEmailSenderDNSPending tells whether the validation failed because the DNS
+// records of the sender aren't visible to Okta yet.
+func isEmailSenderDNSPending(err error) bool {
+	var oktaErr *okta.Error
+	return errors.As(err, &oktaErr) && oktaErr.ErrorCode == emailSenderDNSPendingErrorCode
+}
+
 func resourceEmailSenderVerificationRead(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
 	return nil
 }
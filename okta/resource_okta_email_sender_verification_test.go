@@ -0,0 +1,89 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestIsEmailSenderDNSPending(t *testing.T) {
+	if !isEmailSenderDNSPending(&okta.Error{ErrorCode: emailSenderDNSPendingErrorCode}) {
+		t.Errorf("expected the missing DNS records to be retried")
+	}
+	malformed := &okta.Error{
+		ErrorCode:    "E0000001",
+		ErrorSummary: "Api validation failed: DNS record of type TXT is malformed",
+	}
+	if isEmailSenderDNSPending(malformed) {
+		t.Errorf("expected an error mentioning DNS with another code not to be retried")
+	}
+	if isEmailSenderDNSPending(errors.New("connection reset by peer")) {
+		t.Errorf("expected a non API error not to be reported as pending DNS records")
+	}
+}
+
+func TestVerifyEmailSender(t *testing.T) {
+	newFakeOktaServer(t)
+	var (
+		lock      sync.Mutex
+		responses []*okta.Error
+		requests  int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/org/email/sender/sender-id/validate" {
+			// the user of the token checked when the provider is configured
+			_, _ = w.Write([]byte(`{"id":"00u1"}`))
+			return
+		}
+		requests++
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(responses[0])
+		responses = responses[1:]
+	}))
+	defer srv.Close()
+	t.Setenv("OKTA_HTTP_PROXY", srv.URL)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the test server: %v", err)
+	}
+	verify := func(errs ...*okta.Error) (int, error) {
+		lock.Lock()
+		responses, requests = errs, 0
+		lock.Unlock()
+		err := verifyEmailSender(context.Background(), config.supplementClient, "sender-id", sdk.EmailSenderValidation{}, time.Minute)
+		lock.Lock()
+		defer lock.Unlock()
+		return requests, err
+	}
+
+	// the validation is retried while the DNS records propagate
+	pending := &okta.Error{ErrorCode: emailSenderDNSPendingErrorCode, ErrorSummary: "DNS records not found"}
+	if n, err := verify(pending, pending); err != nil || n != 3 {
+		t.Fatalf("expected the validation to succeed on the third attempt, got %d attempts and %v", n, err)
+	}
+
+	// any other client error fails right away, even if it mentions DNS
+	malformed := &okta.Error{ErrorCode: "E0000001", ErrorSummary: "Api validation failed: DNS record of type TXT is malformed"}
+	start := time.Now()
+	if n, err := verify(malformed, malformed); err == nil || n != 1 {
+		t.Fatalf("expected the validation to fail on the first attempt, got %d attempts and %v", n, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the validation to fail right away, it took %s", elapsed)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceGroup() *schema.Resource {
@@ -87,7 +88,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interfac
 	bOff.MaxElapsedTime = time.Second * 10
 	bOff.InitialInterval = time.Second
 	err = backoff.Retry(func() error {
-		g, resp, err := getOktaClientFromMetadata(m).Group.GetGroup(ctx, responseGroup.Id)
+		g, resp, err := getOktaClientFromMetadata(m).Group.GetGroup(transport.SkipCache(ctx), responseGroup.Id)
 		if err := suppressErrorOn404(resp, err); err != nil {
 			return backoff.Permanent(err)
 		}
@@ -200,11 +201,9 @@ func updateGroupUsers(ctx context.Context, d *schema.ResourceData, m interface{}
 	newSet := newGM.(*schema.Set)
 	usersToAdd := convertInterfaceArrToStringArr(newSet.Difference(oldSet).List())
 	usersToRemove := convertInterfaceArrToStringArr(oldSet.Difference(newSet).List())
-	err := addGroupMembers(ctx, client, d.Id(), usersToAdd)
-	if err != nil {
-		return err
-	}
-	return removeGroupMembers(ctx, client, d.Id(), usersToRemove)
+	jobs := groupMemberJobs(client, d.Id(), usersToAdd, usersToRemove)
+	results := runSetChangeJobs(ctx, d, m, "users", newSet, jobs)
+	return jobsError(results, "failed to update group members")
 }
 
 func buildGroup(d *schema.ResourceData) *okta.Group {
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceGroupCustomSchemaProperty() *schema.Resource {
@@ -137,7 +138,7 @@ func alterCustomGroupSchema(ctx context.Context, m interface{}, index string, sc
 			}
 			return backoff.Permanent(err)
 		}
-		s, _, err := getOktaClientFromMetadata(m).GroupSchema.GetGroupSchema(ctx)
+		s, _, err := getOktaClientFromMetadata(m).GroupSchema.GetGroupSchema(transport.SkipCache(ctx))
 		if err != nil {
 			return backoff.Permanent(fmt.Errorf("failed to get group custom schema property: %v", err))
 		}
@@ -7,20 +7,19 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOktaGroupSchema_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updated := mgr.GetFixtures("basic_updated.tf", ri, t)
 	unique := mgr.GetFixtures("unique.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -88,14 +87,14 @@ func TestAccOktaGroupSchema_crud(t *testing.T) {
 }
 
 func TestAccOktaGroupSchema_arrayString(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
 	mgr := newFixtureManager(groupSchemaProperty)
 	config := mgr.GetFixtures("array_string.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("array_string_updated.tf", ri, t)
 	arrayEnum := mgr.GetFixtures("array_enum.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -201,10 +200,10 @@ func testOktaGroupSchemasExists(name string) resource.TestCheckFunc {
 }
 
 func TestAccResourceOktaGroupSchema_array_enum_number(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -292,10 +291,10 @@ func TestAccResourceOktaGroupSchema_array_enum_number(t *testing.T) {
 }
 
 func TestAccResourceOktaGroupSchema_enum_number(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -379,10 +378,10 @@ func TestAccResourceOktaGroupSchema_enum_number(t *testing.T) {
 }
 
 func TestAccResourceOktaGroupSchema_array_enum_integer(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -470,10 +469,10 @@ func TestAccResourceOktaGroupSchema_array_enum_integer(t *testing.T) {
 }
 
 func TestAccResourceOktaGroupSchema_enum_integer(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -559,10 +558,10 @@ func TestAccResourceOktaGroupSchema_enum_integer(t *testing.T) {
 func TestAccResourceOktaGroupSchema_array_enum_boolean(t *testing.T) {
 	// TODO deal with apparent monolith bug:
 	// "the API returned an error: Array specified in enum field must match const values specified in oneOf field."
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -638,10 +637,10 @@ func TestAccResourceOktaGroupSchema_array_enum_boolean(t *testing.T) {
 func TestAccResourceOktaGroupSchema_enum_boolean(t *testing.T) {
 	// TODO deal with apparent monolith bug:
 	// "the API returned an error: Array specified in enum field must match const values specified in oneOf field."
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -711,10 +710,10 @@ func TestAccResourceOktaGroupSchema_enum_boolean(t *testing.T) {
 }
 
 func TestAccResourceOktaGroupSchema_array_enum_string(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -802,10 +801,10 @@ func TestAccResourceOktaGroupSchema_array_enum_string(t *testing.T) {
 }
 
 func TestAccResourceOktaGroupSchema_enum_string(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(groupSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", groupSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaGroupSchemasDestroy(),
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceGroupMembership() *schema.Resource {
@@ -53,7 +54,7 @@ func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData,
 	bOff.MaxElapsedTime = time.Second * 10
 	bOff.InitialInterval = time.Second
 	err = backoff.Retry(func() error {
-		inGroup, err := checkIfUserInGroup(ctx, client, groupId, userId)
+		inGroup, err := checkIfUserInGroup(transport.SkipCache(ctx), client, groupId, userId)
 		if err != nil {
 			return backoff.Permanent(fmt.Errorf("failed to find user (%s) in group (%s) after addition with error: %v", userId, groupId, err))
 		}
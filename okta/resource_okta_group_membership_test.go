@@ -6,19 +6,18 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaGroupMembership_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 
 	mgr := newFixtureManager(groupMembership)
 	config := mgr.GetFixtures("okta_group_membership.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("okta_group_membership_updated.tf", ri, t)
 	removedConfig := mgr.GetFixtures("okta_group_membership_removed.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(groupMembership, checkMembershipState),
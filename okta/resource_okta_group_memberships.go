@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceGroupMemberships() *schema.Resource {
@@ -18,6 +19,9 @@ func resourceGroupMemberships() *schema.Resource {
 		ReadContext:   resourceGroupMembershipsRead,
 		UpdateContext: resourceGroupMembershipsUpdate,
 		DeleteContext: resourceGroupMembershipsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -54,28 +58,16 @@ func resourceGroupMembershipsCreate(ctx context.Context, d *schema.ResourceData,
 		d.SetId(groupId)
 		return nil
 	}
-	err := addGroupMembers(ctx, client, groupId, users)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	bOff := backoff.NewExponentialBackOff()
-	bOff.MaxElapsedTime = time.Second * 10
-	bOff.InitialInterval = time.Second
-
-	// During create the Okta service can have eventual consistency issues when
-	// adding users to a group. Use a backoff to wait for at list one user to be
-	// associated with the group.
-	err = backoff.Retry(func() error {
-		// TODO, should we wait for all users to be added to the group?
-		ok, err := checkIfGroupHasUsers(ctx, client, groupId, users)
-		if err != nil {
-			return backoff.Permanent(err)
+	jobs := groupMemberJobs(client, groupId, users, nil)
+	results := runSetChangeJobs(ctx, d, m, "users", d.Get("users").(*schema.Set), jobs)
+	if diags := jobsDiagnostics(results); diags.HasError() {
+		// keep track of the users that did get added
+		if len(diags) < len(results) {
+			d.SetId(groupId)
 		}
-		if ok {
-			return nil
-		}
-		return fmt.Errorf("group (%s) did not have expected user memberships after multiple checks", groupId)
-	}, bOff)
+		return diags
+	}
+	err := waitForGroupMemberships(ctx, client, groupId, users, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -117,13 +109,12 @@ func resourceGroupMembershipsRead(ctx context.Context, d *schema.ResourceData, m
 
 func resourceGroupMembershipsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	groupId := d.Get("group_id").(string)
-	users := convertInterfaceToStringSetNullable(d.Get("users"))
+	users := d.Get("users").(*schema.Set)
 	client := getOktaClientFromMetadata(m)
-	err := removeGroupMembers(ctx, client, groupId, users)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
+	jobs := groupMemberJobs(client, groupId, nil, convertInterfaceToStringSetNullable(users))
+	// whatever fails to be removed stays in the state
+	results := runSetChangeJobs(ctx, d, m, "users", schema.NewSet(users.F, nil), jobs)
+	return jobsDiagnostics(results)
 }
 
 func resourceGroupMembershipsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -138,17 +129,9 @@ func resourceGroupMembershipsUpdate(ctx context.Context, d *schema.ResourceData,
 	usersToAdd := convertInterfaceArrToStringArr(newSet.Difference(oldSet).List())
 	usersToRemove := convertInterfaceArrToStringArr(oldSet.Difference(newSet).List())
 
-	err := addGroupMembers(ctx, client, groupId, usersToAdd)
-	if err != nil {
-		diag.FromErr(err)
-	}
-
-	err = removeGroupMembers(ctx, client, groupId, usersToRemove)
-	if err != nil {
-		diag.FromErr(err)
-	}
-
-	return nil
+	jobs := groupMemberJobs(client, groupId, usersToAdd, usersToRemove)
+	results := runSetChangeJobs(ctx, d, m, "users", newSet, jobs)
+	return jobsDiagnostics(results)
 }
 
 // checkIfUsersHaveChanged If the function returns true then users have been
@@ -183,7 +166,7 @@ func checkIfUsersHaveChanged(ctx context.Context, client *okta.Client, groupId s
 
 	for resp.HasNextPage() {
 		groupUsers = nil
-		resp, err = resp.Next(context.Background(), &groupUsers)
+		resp, err = resp.Next(ctx, &groupUsers)
 		if err != nil {
 			return false, &noop, fmt.Errorf("unable to list users for group (%s) from API, error: %+v", groupId, err)
 		}
@@ -239,7 +222,7 @@ func checkIfUsersHaveBeenRemoved(ctx context.Context, client *okta.Client, group
 
 	for resp.HasNextPage() {
 		groupUsers = nil
-		resp, err = resp.Next(context.Background(), &groupUsers)
+		resp, err = resp.Next(ctx, &groupUsers)
 		if err != nil {
 			return false, &noop, fmt.Errorf("unable to list users for group (%s) from API, error: %+v", groupId, err)
 		}
@@ -269,6 +252,28 @@ func checkIfUsersHaveBeenRemoved(ctx context.Context, client *okta.Client, group
 	return true, &newUsers, nil
 }
 
+// waitForGroupMemberships waits for the users added to the group to show up in
+// its memberships. During create the Okta service can have eventual
+// consistency issues when adding users to a group, the memberships are polled
+// until at least one user is associated with the group or until the timeout.
+func waitForGroupMemberships(ctx context.Context, client *okta.Client, groupId string, users []string, timeout time.Duration) error {
+	bOff := backoff.NewExponentialBackOff()
+	bOff.MaxElapsedTime = timeout
+	bOff.InitialInterval = time.Second
+	bc := backoff.WithContext(bOff, ctx)
+	return backoff.Retry(func() error {
+		// TODO, should we wait for all users to be added to the group?
+		ok, err := checkIfGroupHasUsers(transport.SkipCache(ctx), client, groupId, users)
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		if ok {
+			return nil
+		}
+		return fmt.Errorf("group (%s) did not have expected user memberships after multiple checks", groupId)
+	}, bc)
+}
+
 func checkIfGroupHasUsers(ctx context.Context, client *okta.Client, groupId string, users []string) (bool, error) {
 	groupUsers, resp, err := client.Group.ListGroupUsers(ctx, groupId, &query.Params{Limit: defaultPaginationLimit})
 	if err := suppressErrorOn404(resp, err); err != nil {
@@ -1,24 +1,26 @@
 package okta
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccResourceOktaGroupMemberships_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 
 	mgr := newFixtureManager(groupMemberships)
 	start := mgr.GetFixtures("basic.tf", ri, t)
 	update := mgr.GetFixtures("basic_update.tf", ri, t)
 	remove := mgr.GetFixtures("basic_removal.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -38,7 +40,7 @@ func TestAccResourceOktaGroupMemberships_crud(t *testing.T) {
 
 // TestAccResourceOktaGroupMemberships_Issue1072 addresses https://github.com/okta/terraform-provider-okta/issues/1072
 func TestAccResourceOktaGroupMemberships_Issue1072(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -68,7 +70,7 @@ resource "okta_group_memberships" "test" {
 // https://github.com/okta/terraform-provider-okta/issues/1149
 // https://github.com/okta/terraform-provider-okta/issues/1155
 func TestAccResourceOktaGroupMemberships_ClassicBehavior(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -107,7 +109,7 @@ func TestAccResourceOktaGroupMemberships_ClassicBehavior(t *testing.T) {
 // https://github.com/okta/terraform-provider-okta/issues/1149
 // https://github.com/okta/terraform-provider-okta/issues/1155
 func TestAccResourceOktaGroupMemberships_TrackAllUsersBehavior(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -268,7 +270,7 @@ resource "okta_group" "test" {
 		t.SkipNow()
 	}
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -321,3 +323,41 @@ resource "okta_user" "test-%03d" {
 }
 `, i, i, i, i)
 }
+
+func TestWaitForGroupMembershipsHonorsTimeout(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	groupID := srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": "testAcc_empty"}})
+	userID := srv.Put("/users", map[string]interface{}{"profile": map[string]interface{}{"login": "testAcc@example.com"}})
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+
+	// the memberships never show up, the poll ends at the create timeout
+	timeout := 3 * time.Second
+	start := time.Now()
+	err = waitForGroupMemberships(context.Background(), config.oktaClient, groupID, []string{userID}, timeout)
+	if err == nil {
+		t.Fatalf("expected the wait to fail without memberships")
+	}
+	if elapsed := time.Since(start); elapsed > timeout+time.Second {
+		t.Fatalf("expected the wait to end at the %s timeout, it took %s", timeout, elapsed)
+	}
+
+	// and at the deadline of the operation
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	err = waitForGroupMemberships(ctx, config.oktaClient, groupID, []string{userID}, time.Hour)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to stop at the deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("expected the wait to stop at the deadline, it took %s", elapsed)
+	}
+
+	srv.Put("/groups/"+groupID+"/users", map[string]interface{}{"id": userID})
+	if err := waitForGroupMemberships(context.Background(), config.oktaClient, groupID, []string{userID}, timeout); err != nil {
+		t.Fatalf("expected the wait to end once the user is a member, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceGroupOwner() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupOwnerCreate,
+		ReadContext:   resourceGroupOwnerRead,
+		DeleteContext: resourceGroupOwnerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				parts := strings.Split(d.Id(), "/")
+				if len(parts) != 2 {
+					return nil, errors.New("invalid resource import specifier. Use: terraform import <group_id>/<owner_id>")
+				}
+				_ = d.Set("group_id", parts[0])
+				_ = d.Set("id_of_group_owner", parts[1])
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Description: "Resource to manage an owner of a group.",
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of a Okta group.",
+				ForceNew:    true,
+			},
+			"id_of_group_owner": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the user or group owning the group.",
+				ForceNew:    true,
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: elemInSlice([]string{sdk.GroupOwnerTypeUser, sdk.GroupOwnerTypeGroup}),
+				Description:      "Type of the owner: USER or GROUP.",
+				ForceNew:         true,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Display name of the owner.",
+			},
+		},
+	}
+}
+
+func resourceGroupOwnerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	ownerId := d.Get("id_of_group_owner").(string)
+	logger(m).Info("assigning owner to group", "group", groupId, "owner", ownerId)
+	owner := sdk.GroupOwner{
+		ID:   ownerId,
+		Type: d.Get("type").(string),
+	}
+	_, _, err := getSupplementFromMetadata(m).CreateGroupOwner(ctx, groupId, owner)
+	if err != nil {
+		return diag.Errorf("failed to assign owner to group: %v", err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", groupId, ownerId))
+	return resourceGroupOwnerRead(ctx, d, m)
+}
+
+func resourceGroupOwnerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	ownerId := d.Get("id_of_group_owner").(string)
+	owners, resp, err := listGroupOwners(ctx, getSupplementFromMetadata(m), groupId)
+	if is404(resp) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to list owners of group: %v", err)
+	}
+	for _, owner := range owners {
+		if owner.ID == ownerId {
+			_ = d.Set("type", owner.Type)
+			_ = d.Set("display_name", owner.DisplayName)
+			return nil
+		}
+	}
+	logger(m).Info("owner is not assigned to group", "group", groupId, "owner", ownerId)
+	d.SetId("")
+	return nil
+}
+
+func resourceGroupOwnerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	ownerId := d.Get("id_of_group_owner").(string)
+	logger(m).Info("unassigning owner from group", "group", groupId, "owner", ownerId)
+	resp, err := getSupplementFromMetadata(m).DeleteGroupOwner(ctx, groupId, ownerId)
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return diag.Errorf("failed to unassign owner from group: %v", err)
+	}
+	return nil
+}
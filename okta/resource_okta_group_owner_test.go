@@ -0,0 +1,83 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestAccOktaGroupOwner_crud(t *testing.T) {
+	ri := vcrRandInt(t)
+	resourceName := fmt.Sprintf("%s.test", groupOwner)
+	mgr := newFixtureManager(groupOwner)
+	config := mgr.GetFixtures("basic.tf", ri, t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      createCheckResourceDestroy(group, doesGroupExist),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", sdk.GroupOwnerTypeUser),
+					resource.TestCheckResourceAttrPair(resourceName, "id_of_group_owner", "okta_user.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "display_name"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("failed to find %s", resourceName)
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["group_id"], rs.Primary.Attributes["id_of_group_owner"]), nil
+				},
+			},
+		},
+	})
+}
+
+func TestGroupOwnerLifecycle(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	groupId := srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": "testAcc_owned"}})
+	userId := srv.Put("/users", map[string]interface{}{"profile": map[string]interface{}{"login": "testAcc@example.com"}})
+
+	ctx := context.Background()
+	r := resourceGroupOwner()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group_id":          groupId,
+		"id_of_group_owner": userId,
+		"type":              sdk.GroupOwnerTypeUser,
+	})
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to assign group owner: %v", diags)
+	}
+	if d.Id() != groupId+"/"+userId {
+		t.Fatalf("expected ID to be %s/%s, got %q", groupId, userId, d.Id())
+	}
+	if srv.Get("/groups/"+groupId+"/owners/"+userId) == nil {
+		t.Fatal("expected the user to own the group")
+	}
+
+	// an owner unassigned outside of Terraform is picked up on read
+	srv.Delete("/groups/" + groupId + "/owners/" + userId)
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read group owner: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected ID to be cleared when the owner is unassigned, got %q", d.Id())
+	}
+}
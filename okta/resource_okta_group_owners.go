@@ -0,0 +1,151 @@
+package okta
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourceGroupOwners() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupOwnersCreate,
+		ReadContext:   resourceGroupOwnersRead,
+		UpdateContext: resourceGroupOwnersUpdate,
+		DeleteContext: resourceGroupOwnersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				// all the owners of the group are imported
+				owners, _, err := listGroupOwners(ctx, getSupplementFromMetadata(m), d.Id())
+				if err != nil {
+					return nil, err
+				}
+				_ = d.Set("group_id", d.Id())
+				_ = d.Set("owner", flattenGroupOwners(owners))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Description: "Resource to manage a set of owners for a specific group.",
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of a Okta group.",
+				ForceNew:    true,
+			},
+			"owner": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The users and groups which the group should have ownership managed for.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the user or group owning the group.",
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: elemInSlice([]string{sdk.GroupOwnerTypeUser, sdk.GroupOwnerTypeGroup}),
+							Description:      "Type of the owner: USER or GROUP.",
+						},
+					},
+				},
+			},
+			"track_all_owners": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "The resource concerns itself with all owners assigned/unassigned to the group; even those managed outside of the resource.",
+			},
+		},
+	}
+}
+
+func resourceGroupOwnersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	owners := d.Get("owner").(*schema.Set)
+	if owners.Len() == 0 {
+		d.SetId(groupId)
+		return nil
+	}
+	jobs := groupOwnerJobs(getSupplementFromMetadata(m), groupId, owners.List(), nil)
+	results := runSetChangeJobs(ctx, d, m, "owner", owners, jobs)
+	if diags := jobsDiagnostics(results); diags.HasError() {
+		// keep track of the owners that did get assigned
+		if len(diags) < len(results) {
+			d.SetId(groupId)
+		}
+		return diags
+	}
+	d.SetId(groupId)
+	return nil
+}
+
+func resourceGroupOwnersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	owners, resp, err := listGroupOwners(ctx, getSupplementFromMetadata(m), groupId)
+	if is404(resp) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("failed to list owners of group %q: %v", groupId, err)
+	}
+
+	// New behavior, tracking all owners.
+	if d.Get("track_all_owners").(bool) {
+		_ = d.Set("owner", flattenGroupOwners(owners))
+		return nil
+	}
+
+	// Legacy behavior is just to check if any owners have been unassigned
+	// from the group.
+	assigned := make(map[string]bool, len(owners))
+	for _, owner := range owners {
+		assigned[owner.ID] = true
+	}
+	oldOwners := d.Get("owner").(*schema.Set)
+	newOwners := schema.NewSet(oldOwners.F, nil)
+	for _, owner := range oldOwners.List() {
+		if assigned[owner.(map[string]interface{})["id"].(string)] {
+			newOwners.Add(owner)
+		}
+	}
+	if newOwners.Len() != oldOwners.Len() {
+		_ = d.Set("owner", newOwners)
+	}
+	return nil
+}
+
+func resourceGroupOwnersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	oldOwners, newOwners := d.GetChange("owner")
+	oldSet := oldOwners.(*schema.Set)
+	newSet := newOwners.(*schema.Set)
+	jobs := groupOwnerJobs(getSupplementFromMetadata(m), groupId, newSet.Difference(oldSet).List(), oldSet.Difference(newSet).List())
+	results := runSetChangeJobs(ctx, d, m, "owner", newSet, jobs)
+	return jobsDiagnostics(results)
+}
+
+func resourceGroupOwnersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	owners := d.Get("owner").(*schema.Set)
+	jobs := groupOwnerJobs(getSupplementFromMetadata(m), groupId, nil, owners.List())
+	// whatever fails to be unassigned stays in the state
+	results := runSetChangeJobs(ctx, d, m, "owner", schema.NewSet(owners.F, nil), jobs)
+	return jobsDiagnostics(results)
+}
+
+func flattenGroupOwners(owners []*sdk.GroupOwner) []interface{} {
+	arr := make([]interface{}, len(owners))
+	for i, owner := range owners {
+		arr[i] = map[string]interface{}{
+			"id":   owner.ID,
+			"type": owner.Type,
+		}
+	}
+	return arr
+}
@@ -0,0 +1,125 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestAccOktaGroupOwners_crud(t *testing.T) {
+	ri := vcrRandInt(t)
+	resourceName := fmt.Sprintf("%s.test", groupOwners)
+	mgr := newFixtureManager(groupOwners)
+	config := mgr.GetFixtures("basic.tf", ri, t)
+	updated := mgr.GetFixtures("basic_updated.tf", ri, t)
+	trackAll := mgr.GetFixtures("track_all_owners.tf", ri, t)
+
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      createCheckResourceDestroy(group, doesGroupExist),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "owner.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "owner.*", map[string]string{"type": sdk.GroupOwnerTypeGroup}),
+				),
+			},
+			{
+				Config: updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "owner.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "owner.*.id", "okta_user.test2", "id"),
+				),
+			},
+			{
+				Config: trackAll,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "track_all_owners", "true"),
+					resource.TestCheckResourceAttr(resourceName, "owner.#", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"track_all_owners"},
+			},
+		},
+	})
+}
+
+func TestGroupOwnersTrackAllOwners(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	groupId := srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": "testAcc_owned"}})
+	ownerGroupId := srv.Put("/groups", map[string]interface{}{"profile": map[string]interface{}{"name": "testAcc_owner"}})
+	user1 := srv.Put("/users", map[string]interface{}{"profile": map[string]interface{}{"login": "testAcc1@example.com"}})
+	user2 := srv.Put("/users", map[string]interface{}{"profile": map[string]interface{}{"login": "testAcc2@example.com"}})
+
+	ctx := context.Background()
+	r := resourceGroupOwners()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group_id": groupId,
+		"owner": []interface{}{
+			map[string]interface{}{"id": user1, "type": sdk.GroupOwnerTypeUser},
+			map[string]interface{}{"id": ownerGroupId, "type": sdk.GroupOwnerTypeGroup},
+		},
+	})
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to assign group owners: %v", diags)
+	}
+	if d.Id() != groupId {
+		t.Fatalf("expected ID to be the group ID %q, got %q", groupId, d.Id())
+	}
+
+	// outside of Terraform, user2 is assigned and the owning group unassigned
+	srv.Put("/groups/"+groupId+"/owners", map[string]interface{}{"id": user2, "type": sdk.GroupOwnerTypeUser})
+	srv.Delete("/groups/" + groupId + "/owners/" + ownerGroupId)
+
+	// only the unassigned owner is picked up by default
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read group owners: %v", diags)
+	}
+	if got := groupOwnerIDs(d); !reflect.DeepEqual(got, []string{user1}) {
+		t.Fatalf("expected owners [%s], got %v", user1, got)
+	}
+
+	// the owners assigned elsewhere too when all of them are tracked
+	_ = d.Set("track_all_owners", true)
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to read group owners: %v", diags)
+	}
+	expected := []string{user1, user2}
+	sort.Strings(expected)
+	if got := groupOwnerIDs(d); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected owners %v, got %v", expected, got)
+	}
+
+	if diags := r.DeleteContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("failed to unassign group owners: %v", diags)
+	}
+	owners, _, err := listGroupOwners(ctx, config.supplementClient, groupId)
+	if err != nil || len(owners) != 0 {
+		t.Fatalf("expected the group to have no owners left, got %+v, %v", owners, err)
+	}
+}
+
+func groupOwnerIDs(d *schema.ResourceData) []string {
+	var ids []string
+	for _, owner := range d.Get("owner").(*schema.Set).List() {
+		ids = append(ids, owner.(map[string]interface{})["id"].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
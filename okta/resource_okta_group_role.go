@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceGroupRole() *schema.Resource {
@@ -118,7 +119,7 @@ func resourceGroupRoleCreate(ctx context.Context, d *schema.ResourceData, m inte
 	bOff.MaxElapsedTime = time.Second * 10
 	bOff.InitialInterval = time.Second
 	err = backoff.Retry(func() error {
-		err := resourceGroupRoleRead(ctx, d, m)
+		err := resourceGroupRoleRead(transport.SkipCache(ctx), d, m)
 		if err != nil {
 			return backoff.Permanent(fmt.Errorf("%s", err[0].Summary))
 		}
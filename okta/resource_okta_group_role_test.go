@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaGroupAdminRole_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", groupRole)
 	resourceName2 := fmt.Sprintf("%s.test_app", groupRole)
 	mgr := newFixtureManager(groupRole)
@@ -18,7 +17,7 @@ func TestAccOktaGroupAdminRole_crud(t *testing.T) {
 	groupTargetsUpdated := mgr.GetFixtures("group_targets_updated.tf", ri, t)
 	groupTargetsRemoved := mgr.GetFixtures("group_targets_removed.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(group, doesGroupExist),
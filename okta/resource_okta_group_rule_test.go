@@ -6,23 +6,22 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaGroupRule_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", groupRule)
 	mgr := newFixtureManager("okta_group_rule")
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("basic_updated.tf", ri, t)
 	name := buildResourceName(ri)
-	ri = acctest.RandInt()
+	ri = vcrRandInt(t)
 	groupUpdate := mgr.GetFixtures("basic_group_update.tf", ri, t)
 	deactivated := mgr.GetFixtures("basic_deactivated.tf", ri, t)
 	name2 := buildResourceName(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(groupRule, doesGroupRuleExist),
@@ -63,7 +62,7 @@ func TestAccOktaGroupRule_crud(t *testing.T) {
 }
 
 func TestAccOktaGroupRule_invalidHandle(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	groupResource := fmt.Sprintf("%s.test", group)
 	ruleResource := fmt.Sprintf("%s.inval", groupRule)
 	testName := buildResourceName(ri)
@@ -72,7 +71,7 @@ func TestAccOktaGroupRule_invalidHandle(t *testing.T) {
 	testRun := buildInvalidTest(testName)
 	testUpdate := buildInvalidUpdate(testName)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaIdpSocial_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(idpSocial)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	disabledConf := mgr.GetFixtures("auto_provision_disabled.tf", ri, t)
@@ -17,7 +16,7 @@ func TestAccOktaIdpSocial_crud(t *testing.T) {
 	microName := fmt.Sprintf("%s.microsoft", idpSocial)
 	googleName := fmt.Sprintf("%s.google", idpSocial)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(idpSocial, createDoesIdpExist()),
@@ -5,12 +5,11 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaInlineHook_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := "okta_inline_hook.test"
 	mgr := newFixtureManager(inlineHook)
 	config := mgr.GetFixtures("basic.tf", ri, t)
@@ -19,7 +18,7 @@ func TestAccOktaInlineHook_crud(t *testing.T) {
 	registration := mgr.GetFixtures("registration.tf", ri, t)
 	passwordImport := mgr.GetFixtures("password_import.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createCheckResourceDestroy(inlineHook, inlineHookExists),
@@ -0,0 +1,335 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+var (
+	awsAccountIDRegex       = regexp.MustCompile(`^\d{12}$`)
+	awsEventSourceNameRegex = regexp.MustCompile(`^[.\-_A-Za-z0-9]{1,75}$`)
+	awsRegionRegex          = regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-\d$`)
+	hostNameRegex           = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)+$`)
+)
+
+type logStreamResourceType struct{}
+
+type logStreamResource struct {
+	provider *frameworkProvider
+}
+
+type logStreamModel struct {
+	ID       types.String             `tfsdk:"id"`
+	Name     types.String             `tfsdk:"name"`
+	Type     types.String             `tfsdk:"type"`
+	Status   types.String             `tfsdk:"status"`
+	Settings []logStreamSettingsModel `tfsdk:"settings"`
+}
+
+type logStreamSettingsModel struct {
+	AccountID       types.String `tfsdk:"account_id"`
+	EventSourceName types.String `tfsdk:"event_source_name"`
+	Region          types.String `tfsdk:"region"`
+	Edition         types.String `tfsdk:"edition"`
+	Host            types.String `tfsdk:"host"`
+	Token           types.String `tfsdk:"token"`
+}
+
+func (logStreamResourceType) GetSchema(context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		Description: "Manages a log stream, which streams the System Log events of the org to AWS EventBridge or Splunk Cloud.",
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				Type:          types.StringType,
+				Computed:      true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.UseStateForUnknown()},
+				Description:   "ID of the log stream",
+			},
+			"name": {
+				Type:        types.StringType,
+				Required:    true,
+				Description: "Name of the log stream",
+			},
+			"type": {
+				Type:          types.StringType,
+				Required:      true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+				Validators:    []tfsdk.AttributeValidator{stringOneOf(sdk.LogStreamTypeAWSEventBridge, sdk.LogStreamTypeSplunkCloud)},
+				Description:   "Type of the log stream: aws_eventbridge or splunk_cloud_logstreaming",
+			},
+			"status": {
+				Type:          types.StringType,
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{stringDefault(statusActive)},
+				Validators:    []tfsdk.AttributeValidator{stringOneOf(statusActive, statusInactive)},
+				Description:   "Status of the log stream: ACTIVE or INACTIVE, the default is ACTIVE",
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"settings": {
+				NestingMode: tfsdk.BlockNestingModeList,
+				MinItems:    1,
+				MaxItems:    1,
+				Description: "Settings of the log stream, of its type",
+				Attributes: map[string]tfsdk.Attribute{
+					"account_id": {
+						Type:          types.StringType,
+						Optional:      true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+						Validators:    []tfsdk.AttributeValidator{stringMatchesRegex(awsAccountIDRegex, "AWS account ID")},
+						Description:   "ID of the AWS account of the event source, for aws_eventbridge log streams",
+					},
+					"event_source_name": {
+						Type:          types.StringType,
+						Optional:      true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+						Validators:    []tfsdk.AttributeValidator{stringMatchesRegex(awsEventSourceNameRegex, "event source name")},
+						Description:   "Name of the AWS EventBridge partner event source, for aws_eventbridge log streams",
+					},
+					"region": {
+						Type:          types.StringType,
+						Optional:      true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+						Validators:    []tfsdk.AttributeValidator{stringMatchesRegex(awsRegionRegex, "AWS region")},
+						Description:   "AWS region of the event source, for aws_eventbridge log streams",
+					},
+					"edition": {
+						Type:          types.StringType,
+						Optional:      true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{tfsdk.RequiresReplace()},
+						Validators:    []tfsdk.AttributeValidator{stringOneOf("aws", "aws_govcloud", "gcp")},
+						Description:   "Edition of the Splunk Cloud instance: aws, aws_govcloud or gcp, for splunk_cloud_logstreaming log streams",
+					},
+					"host": {
+						Type:        types.StringType,
+						Optional:    true,
+						Validators:  []tfsdk.AttributeValidator{stringMatchesRegex(hostNameRegex, "host name, without a scheme like https://")},
+						Description: "Host of the Splunk Cloud instance, e.g. acme.splunkcloud.com, for splunk_cloud_logstreaming log streams",
+					},
+					"token": {
+						Type:        types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "HTTP Event Collector token of the Splunk Cloud instance, for splunk_cloud_logstreaming log streams",
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (logStreamResourceType) NewResource(_ context.Context, p tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	return &logStreamResource{provider: p.(*frameworkProvider)}, nil
+}
+
+// ValidateConfig checks the settings are the ones of the type of the log
+// stream, the settings of both types are in the same block.
+func (r *logStreamResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var config logStreamModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Type.Unknown || len(config.Settings) != 1 {
+		return
+	}
+	settings := config.Settings[0]
+	aws := map[string]types.String{
+		"account_id":        settings.AccountID,
+		"event_source_name": settings.EventSourceName,
+		"region":            settings.Region,
+	}
+	splunk := map[string]types.String{
+		"edition": settings.Edition,
+		"host":    settings.Host,
+		"token":   settings.Token,
+	}
+	required, unsupported := aws, splunk
+	if config.Type.Value == sdk.LogStreamTypeSplunkCloud {
+		required, unsupported = splunk, aws
+	}
+	for name, value := range required {
+		if value.Null {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtListIndex(0).AtName(name), "Missing setting",
+				fmt.Sprintf("%s is required for %s log streams", name, config.Type.Value))
+		}
+	}
+	for name, value := range unsupported {
+		if !value.Null {
+			resp.Diagnostics.AddAttributeError(path.Root("settings").AtListIndex(0).AtName(name), "Unsupported setting",
+				fmt.Sprintf("%s isn't a setting of %s log streams", name, config.Type.Value))
+		}
+	}
+}
+
+func (r *logStreamResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var plan logStreamModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, logStream, "create")
+	defer func() { endFrameworkSpan(span, plan.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.provider.config.supplementClient
+	r.provider.config.logger.Info("creating log stream", "name", plan.Name.Value)
+	created, _, err := client.CreateLogStream(ctx, buildLogStream(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create log stream", err.Error())
+		return
+	}
+	plan.ID = types.String{Value: created.ID}
+	if plan.Status.Value == statusInactive {
+		_, err := client.DeactivateLogStream(ctx, created.ID)
+		if err != nil {
+			// the log stream exists, it is saved in the state so it isn't lost
+			_ = resp.State.Set(ctx, &plan)
+			resp.Diagnostics.AddError("Failed to deactivate log stream", err.Error())
+			return
+		}
+	}
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *logStreamResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var state logStreamModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, logStream, "read")
+	defer func() { endFrameworkSpan(span, state.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.read(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.ID.Null {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *logStreamResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan, state logStreamModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, logStream, "update")
+	defer func() { endFrameworkSpan(span, state.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.provider.config.supplementClient
+	r.provider.config.logger.Info("updating log stream", "id", state.ID.Value, "name", plan.Name.Value)
+	_, _, err := client.UpdateLogStream(ctx, state.ID.Value, buildLogStream(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update log stream", err.Error())
+		return
+	}
+	if plan.Status.Value != state.Status.Value {
+		if plan.Status.Value == statusActive {
+			_, err = client.ActivateLogStream(ctx, state.ID.Value)
+		} else {
+			_, err = client.DeactivateLogStream(ctx, state.ID.Value)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to change the status of log stream", err.Error())
+			return
+		}
+	}
+	plan.ID = state.ID
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deactivates the log stream first, as an active log stream can't be
+// deleted.
+func (r *logStreamResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var state logStreamModel
+	ctx, span := startFrameworkSpan(ctx, r.provider.config, logStream, "delete")
+	defer func() { endFrameworkSpan(span, state.ID.Value, resp.Diagnostics) }()
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.provider.config.supplementClient
+	r.provider.config.logger.Info("deleting log stream", "id", state.ID.Value, "name", state.Name.Value)
+	if state.Status.Value == statusActive {
+		apiResp, err := client.DeactivateLogStream(ctx, state.ID.Value)
+		if err := suppressErrorOn404(apiResp, err); err != nil {
+			resp.Diagnostics.AddError("Failed to deactivate log stream", err.Error())
+			return
+		}
+	}
+	apiResp, err := client.DeleteLogStream(ctx, state.ID.Value)
+	if err := suppressErrorOn404(apiResp, err); err != nil {
+		resp.Diagnostics.AddError("Failed to delete log stream", err.Error())
+	}
+}
+
+func (r *logStreamResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// read sets the model to the log stream of its ID, the ID is set to null when
+// the log stream doesn't exist. The token of the Splunk Cloud settings isn't
+// returned by the API, it is kept as it is.
+func (r *logStreamResource) read(ctx context.Context, model *logStreamModel, diags *diag.Diagnostics) {
+	stream, resp, err := r.provider.config.supplementClient.GetLogStream(ctx, model.ID.Value)
+	if err := suppressErrorOn404(resp, err); err != nil {
+		diags.AddError("Failed to get log stream", err.Error())
+		return
+	}
+	if stream == nil {
+		model.ID = types.String{Null: true}
+		return
+	}
+	token := types.String{Null: true}
+	if len(model.Settings) == 1 {
+		token = model.Settings[0].Token
+	}
+	model.Name = types.String{Value: stream.Name}
+	model.Type = types.String{Value: stream.Type}
+	model.Status = types.String{Value: stream.Status}
+	model.Settings = nil
+	if stream.Settings != nil {
+		model.Settings = []logStreamSettingsModel{{
+			AccountID:       stringValue(stream.Settings.AccountID),
+			EventSourceName: stringValue(stream.Settings.EventSourceName),
+			Region:          stringValue(stream.Settings.Region),
+			Edition:         stringValue(stream.Settings.Edition),
+			Host:            stringValue(stream.Settings.Host),
+			Token:           token,
+		}}
+	}
+}
+
+func buildLogStream(model logStreamModel) sdk.LogStream {
+	stream := sdk.LogStream{
+		Name: model.Name.Value,
+		Type: model.Type.Value,
+	}
+	if len(model.Settings) == 1 {
+		settings := model.Settings[0]
+		stream.Settings = &sdk.LogStreamSettings{
+			AccountID:       settings.AccountID.Value,
+			EventSourceName: settings.EventSourceName.Value,
+			Region:          settings.Region.Value,
+			Edition:         settings.Edition.Value,
+			Host:            settings.Host.Value,
+			Token:           settings.Token.Value,
+		}
+	}
+	return stream
+}
@@ -0,0 +1,189 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func TestAccOktaLogStream(t *testing.T) {
+	ri := vcrRandInt(t)
+	mgr := newFixtureManager(logStream)
+	config := mgr.GetFixtures("basic.tf", ri, t)
+	updated := mgr.GetFixtures("basic_updated.tf", ri, t)
+	resourceName := fmt.Sprintf("%s.test", logStream)
+	oktaResourceTest(
+		t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+			CheckDestroy:             createCheckResourceDestroy(logStream, doesLogStreamExist),
+			Steps: []resource.TestStep{
+				{
+					Config: config,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(ri)),
+						resource.TestCheckResourceAttr(resourceName, "type", sdk.LogStreamTypeAWSEventBridge),
+						resource.TestCheckResourceAttr(resourceName, "status", statusActive),
+						resource.TestCheckResourceAttr(resourceName, "settings.0.account_id", "123456789012"),
+						resource.TestCheckResourceAttr(resourceName, "settings.0.event_source_name", buildResourceName(ri)),
+						resource.TestCheckResourceAttr(resourceName, "settings.0.region", "us-east-1"),
+					),
+				},
+				{
+					Config: updated,
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr(resourceName, "name", buildResourceName(ri)+"_updated"),
+						resource.TestCheckResourceAttr(resourceName, "status", statusInactive),
+						resource.TestCheckResourceAttr(resourceName, "settings.0.account_id", "123456789012"),
+					),
+				},
+				{
+					ResourceName:      resourceName,
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+}
+
+func doesLogStreamExist(id string) (bool, error) {
+	_, response, err := getSupplementFromMetadata(testAccProvider.Meta()).GetLogStream(context.Background(), id)
+	return doesResourceExist(response, err)
+}
+
+// TestLogStreamLifecycle runs the create, update, read and delete of an
+// inactive log stream against the fake server, checking the lifecycle
+// operations are called around the create, update and delete.
+func TestLogStreamLifecycle(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	ctx := context.Background()
+	s, _ := logStreamResourceType{}.GetSchema(ctx)
+	r := &logStreamResource{provider: &frameworkProvider{config: config}}
+	model := logStreamModel{
+		ID:     types.String{Unknown: true},
+		Name:   types.String{Value: "stream"},
+		Type:   types.String{Value: sdk.LogStreamTypeAWSEventBridge},
+		Status: types.String{Value: statusInactive},
+		Settings: []logStreamSettingsModel{{
+			AccountID:       types.String{Value: "123456789012"},
+			EventSourceName: types.String{Value: "stream"},
+			Region:          types.String{Value: "us-east-1"},
+			Edition:         types.String{Null: true},
+			Host:            types.String{Null: true},
+			Token:           types.String{Null: true},
+		}},
+	}
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	createResp := tfsdk.CreateResourceResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.TerraformType(ctx), nil)}}
+	r.Create(ctx, tfsdk.CreateResourceRequest{Plan: plan}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("failed to create log stream: %v", createResp.Diagnostics)
+	}
+	var state logStreamModel
+	createResp.State.Get(ctx, &state)
+	id := state.ID.Value
+	if state.Status.Value != statusInactive || state.Settings[0].AccountID.Value != "123456789012" {
+		t.Fatalf("unexpected state after create: %+v", state)
+	}
+	expected := []string{
+		"POST /api/v1/logStreams",
+		"POST /api/v1/logStreams/" + id + "/lifecycle/deactivate",
+		"GET /api/v1/logStreams/" + id,
+	}
+	requests := srv.Requests()
+	if !reflect.DeepEqual(requests[len(requests)-3:], expected) {
+		t.Fatalf("expected the log stream to be deactivated once created, got %v", requests)
+	}
+
+	model = state
+	model.Name = types.String{Value: "stream_updated"}
+	model.Status = types.String{Value: statusActive}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	updateResp := tfsdk.UpdateResourceResponse{State: createResp.State}
+	r.Update(ctx, tfsdk.UpdateResourceRequest{Plan: plan, State: createResp.State}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("failed to update log stream: %v", updateResp.Diagnostics)
+	}
+	updateResp.State.Get(ctx, &state)
+	if state.Name.Value != "stream_updated" || state.Status.Value != statusActive {
+		t.Fatalf("unexpected state after update: %+v", state)
+	}
+	if item := srv.Get("/logStreams/" + id); item["name"] != "stream_updated" || item["status"] != statusActive {
+		t.Fatalf("expected the log stream to be renamed and activated, got %v", item)
+	}
+
+	r.Delete(ctx, tfsdk.DeleteResourceRequest{State: updateResp.State}, &tfsdk.DeleteResourceResponse{})
+	requests = srv.Requests()
+	expected = []string{
+		"POST /api/v1/logStreams/" + id + "/lifecycle/deactivate",
+		"DELETE /api/v1/logStreams/" + id,
+	}
+	if !reflect.DeepEqual(requests[len(requests)-2:], expected) {
+		t.Fatalf("expected the log stream to be deactivated before it is deleted, got %v", requests)
+	}
+	if srv.Get("/logStreams/"+id) != nil {
+		t.Fatal("expected the log stream to be deleted")
+	}
+
+	readResp := tfsdk.ReadResourceResponse{State: updateResp.State}
+	r.Read(ctx, tfsdk.ReadResourceRequest{State: updateResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("failed to read log stream: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected the deleted log stream to be removed from the state")
+	}
+}
+
+func TestLogStreamValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	s, _ := logStreamResourceType{}.GetSchema(ctx)
+	r := &logStreamResource{}
+	model := logStreamModel{
+		ID:     types.String{Null: true},
+		Name:   types.String{Value: "stream"},
+		Type:   types.String{Value: sdk.LogStreamTypeSplunkCloud},
+		Status: types.String{Null: true},
+		Settings: []logStreamSettingsModel{{
+			AccountID:       types.String{Value: "123456789012"},
+			EventSourceName: types.String{Null: true},
+			Region:          types.String{Null: true},
+			Edition:         types.String{Value: "aws"},
+			Host:            types.String{Value: "acme.splunkcloud.com"},
+			Token:           types.String{Null: true},
+		}},
+	}
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	var resp tfsdk.ValidateResourceConfigResponse
+	r.ValidateConfig(ctx, tfsdk.ValidateResourceConfigRequest{Config: tfsdk.Config{Schema: s, Raw: plan.Raw}}, &resp)
+	var summaries []string
+	for _, d := range resp.Diagnostics {
+		summaries = append(summaries, d.Summary()+": "+d.Detail())
+	}
+	expected := []string{
+		"Missing setting: token is required for splunk_cloud_logstreaming log streams",
+		"Unsupported setting: account_id isn't a setting of splunk_cloud_logstreaming log streams",
+	}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Fatalf("expected diagnostics %v, got %v", expected, summaries)
+	}
+}
@@ -0,0 +1,137 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourcePolicyOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyOrderCreate,
+		ReadContext:   resourcePolicyOrderRead,
+		UpdateContext: resourcePolicyOrderUpdate,
+		DeleteContext: resourcePolicyOrderDelete,
+		Importer:      &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext},
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: elemInSlice([]string{
+					sdk.SignOnPolicyType, sdk.PasswordPolicyType, sdk.MfaPolicyType,
+					sdk.ProfileEnrollmentPolicyType,
+				}),
+				Description: "Type of the policies",
+			},
+			"policy_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "IDs of all the policies of the type except the default one, from the highest priority to the lowest",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePolicyOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyType := d.Get("type").(string)
+	if err := orderPolicies(ctx, m, policyType, convertInterfaceToStringArr(d.Get("policy_ids"))); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policyType)
+	return resourcePolicyOrderRead(ctx, d, m)
+}
+
+func resourcePolicyOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyIDs, err := listOrderedPolicyIDs(ctx, getOktaClientFromMetadata(m), d.Id())
+	if err != nil {
+		return diag.Errorf("failed to list %s policies: %v", d.Id(), err)
+	}
+	if old := convertInterfaceToStringArr(d.Get("policy_ids")); len(old) > 0 && !stringSlicesEqual(old, policyIDs) {
+		logger(m).Warn("policies were reordered or changed outside of Terraform", "type", d.Id())
+	}
+	_ = d.Set("type", d.Id())
+	_ = d.Set("policy_ids", policyIDs)
+	return nil
+}
+
+func resourcePolicyOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := orderPolicies(ctx, m, d.Id(), convertInterfaceToStringArr(d.Get("policy_ids"))); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyOrderRead(ctx, d, m)
+}
+
+// resourcePolicyOrderDelete only removes the resource from the state, the
+// policies keep their priorities.
+func resourcePolicyOrderDelete(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return nil
+}
+
+// orderPolicies updates the priorities of the policies of the type so they are
+// in the order of policyIDs, with as few updates as possible.
+func orderPolicies(ctx context.Context, m interface{}, policyType string, policyIDs []string) error {
+	current, err := listOrderedPolicyIDs(ctx, getOktaClientFromMetadata(m), policyType)
+	if err != nil {
+		return fmt.Errorf("failed to list %s policies: %v", policyType, err)
+	}
+	moves, err := priorityMoves(current, policyIDs)
+	if err != nil {
+		return fmt.Errorf("invalid order of %s policies: policy %v", policyType, err)
+	}
+	client := getSupplementFromMetadata(m)
+	for _, move := range moves {
+		logger(m).Info("updating policy priority", "type", policyType, "policy_id", move.id, "priority", move.priority)
+		policy, _, err := client.GetPolicy(ctx, move.id)
+		if err != nil {
+			return fmt.Errorf("failed to get policy %s: %v", move.id, err)
+		}
+		policy.Priority = move.priority
+		_, _, err = client.UpdatePolicy(ctx, move.id, *policy)
+		if err != nil {
+			return fmt.Errorf("failed to update priority of policy %s: %v", move.id, err)
+		}
+	}
+	return nil
+}
+
+// listOrderedPolicyIDs returns the IDs of the policies of the type, the default
+// policy aside, by priority.
+func listOrderedPolicyIDs(ctx context.Context, client *okta.Client, policyType string) ([]string, error) {
+	policies, resp, err := client.Policy.ListPolicies(ctx, &query.Params{Type: policyType})
+	if err != nil {
+		return nil, err
+	}
+	var all []*okta.Policy
+	for {
+		for _, policy := range policies {
+			all = append(all, policy.(*okta.Policy))
+		}
+		if !resp.HasNextPage() {
+			break
+		}
+		resp, err = resp.Next(ctx, &policies)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Priority < all[j].Priority
+	})
+	policyIDs := []string{}
+	for _, policy := range all {
+		if policy.System != nil && *policy.System {
+			continue
+		}
+		policyIDs = append(policyIDs, policy.Id)
+	}
+	return policyIDs, nil
+}
@@ -0,0 +1,57 @@
+package okta
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestPolicyOrderUpdatesOutOfOrderPolicies checks the policies are reordered
+// with a single update against the fake server, which stores the priorities as
+// they are sent rather than renumbering the other policies like Okta does.
+func TestPolicyOrderUpdatesOutOfOrderPolicies(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	var ids []string
+	for i, name := range []string{"a", "b", "c"} {
+		ids = append(ids, srv.Put("/policies", map[string]interface{}{
+			"type": "PASSWORD", "name": name, "priority": i + 1, "system": false,
+		}))
+	}
+	srv.Put("/policies", map[string]interface{}{"type": "PASSWORD", "name": "Default Policy", "priority": 4, "system": true})
+	srv.Put("/policies", map[string]interface{}{"type": "OKTA_SIGN_ON", "name": "other", "priority": 1, "system": false})
+
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	r := resourcePolicyOrder()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"type":       "PASSWORD",
+		"policy_ids": []interface{}{ids[2], ids[0], ids[1]},
+	})
+	if diags := r.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("failed to order policies: %v", diags)
+	}
+	var updates []string
+	for _, r := range srv.Requests() {
+		if strings.HasPrefix(r, "PUT ") {
+			updates = append(updates, r)
+		}
+	}
+	if len(updates) != 1 || updates[0] != "PUT /api/v1/policies/"+ids[2] {
+		t.Fatalf("expected only the last policy to be moved, got %v", updates)
+	}
+	if p := srv.Get("/policies/" + ids[2])["priority"]; p != float64(1) {
+		t.Fatalf("expected the last policy to be moved to priority 1, got %v", p)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"type":       "PASSWORD",
+		"policy_ids": []interface{}{ids[0], ids[1]},
+	})
+	if diags := r.CreateContext(context.Background(), d, config); !diags.HasError() {
+		t.Fatal("expected an error when a policy isn't listed")
+	}
+}
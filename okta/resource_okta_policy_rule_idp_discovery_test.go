@@ -4,22 +4,21 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaPolicyRuleIdpDiscovery_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(policyRuleIdpDiscovery)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("basic_domain.tf", ri, t)
 	deactivatedConfig := mgr.GetFixtures("basic_deactivated.tf", ri, t)
-	ri2 := acctest.RandInt()
+	ri2 := vcrRandInt(t)
 	appIncludeConfig := mgr.GetFixtures("app_include.tf", ri2, t)
 	appExcludeConfig := mgr.GetFixtures("app_exclude_platform.tf", ri2, t)
 	resourceName := fmt.Sprintf("%s.test", policyRuleIdpDiscovery)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createRuleCheckDestroy(policyRuleIdpDiscovery),
@@ -0,0 +1,123 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/okta/terraform-provider-okta/sdk"
+)
+
+func resourcePolicyRuleOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyRuleOrderCreate,
+		ReadContext:   resourcePolicyRuleOrderRead,
+		UpdateContext: resourcePolicyRuleOrderUpdate,
+		DeleteContext: resourcePolicyRuleOrderDelete,
+		Importer:      &schema.ResourceImporter{StateContext: schema.ImportStatePassthroughContext},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the policy",
+			},
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "IDs of all the rules of the policy except the default one, from the highest priority to the lowest",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePolicyRuleOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	policyID := d.Get("policy_id").(string)
+	if err := orderPolicyRules(ctx, m, policyID, convertInterfaceToStringArr(d.Get("rule_ids"))); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policyID)
+	return resourcePolicyRuleOrderRead(ctx, d, m)
+}
+
+func resourcePolicyRuleOrderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	ruleIDs, err := listOrderedPolicyRuleIDs(ctx, getSupplementFromMetadata(m), d.Id())
+	if err != nil {
+		return diag.Errorf("failed to list rules of policy %s: %v", d.Id(), err)
+	}
+	if ruleIDs == nil {
+		d.SetId("")
+		return nil
+	}
+	if old := convertInterfaceToStringArr(d.Get("rule_ids")); len(old) > 0 && !stringSlicesEqual(old, ruleIDs) {
+		logger(m).Warn("rules of the policy were reordered or changed outside of Terraform", "policy_id", d.Id())
+	}
+	_ = d.Set("policy_id", d.Id())
+	_ = d.Set("rule_ids", ruleIDs)
+	return nil
+}
+
+func resourcePolicyRuleOrderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := orderPolicyRules(ctx, m, d.Id(), convertInterfaceToStringArr(d.Get("rule_ids"))); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyRuleOrderRead(ctx, d, m)
+}
+
+// resourcePolicyRuleOrderDelete only removes the resource from the state, the
+// rules keep their priorities.
+func resourcePolicyRuleOrderDelete(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return nil
+}
+
+// orderPolicyRules updates the priorities of the rules of the policy so they
+// are in the order of ruleIDs, with as few updates as possible.
+func orderPolicyRules(ctx context.Context, m interface{}, policyID string, ruleIDs []string) error {
+	client := getSupplementFromMetadata(m)
+	current, err := listOrderedPolicyRuleIDs(ctx, client, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to list rules of policy %s: %v", policyID, err)
+	}
+	if current == nil {
+		return fmt.Errorf("policy %s doesn't exist", policyID)
+	}
+	moves, err := priorityMoves(current, ruleIDs)
+	if err != nil {
+		return fmt.Errorf("invalid order of the rules of policy %s: rule %v", policyID, err)
+	}
+	for _, move := range moves {
+		logger(m).Info("updating policy rule priority", "policy_id", policyID, "rule_id", move.id, "priority", move.priority)
+		_, err := client.UpdatePolicyRulePriority(ctx, policyID, move.id, move.priority)
+		if err != nil {
+			return fmt.Errorf("failed to update priority of rule %s of policy %s: %v", move.id, policyID, err)
+		}
+	}
+	return nil
+}
+
+// listOrderedPolicyRuleIDs returns the IDs of the rules of the policy, the
+// default rule aside, by priority. It returns nil when the policy doesn't exist.
+func listOrderedPolicyRuleIDs(ctx context.Context, client *sdk.APISupplement, policyID string) ([]string, error) {
+	rules, resp, err := client.ListPolicyRules(ctx, policyID)
+	if err := suppressErrorOn404(resp, err); err != nil {
+		return nil, err
+	}
+	if is404(resp) {
+		return nil, nil
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+	ruleIDs := []string{}
+	for _, rule := range rules {
+		if rule.System != nil && *rule.System {
+			continue
+		}
+		ruleIDs = append(ruleIDs, rule.Id)
+	}
+	return ruleIDs, nil
+}
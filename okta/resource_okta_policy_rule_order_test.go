@@ -0,0 +1,48 @@
+package okta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOktaPolicyRuleOrder(t *testing.T) {
+	ri := vcrRandInt(t)
+	mgr := newFixtureManager(policyRuleOrder)
+	config := mgr.GetFixtures("basic.tf", ri, t)
+	updatedConfig := mgr.GetFixtures("basic_updated.tf", ri, t)
+	resourceName := fmt.Sprintf("%s.test", policyRuleOrder)
+	ruleName := func(name string) string {
+		return fmt.Sprintf("%s.%s", policyRuleSignOn, name)
+	}
+	oktaResourceTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProvidersFactories,
+		CheckDestroy:      createRuleCheckDestroy(policyRuleSignOn),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule_ids.#", "3"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.0", ruleName("one"), "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.2", ruleName("three"), "id"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule_ids.#", "3"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.0", ruleName("three"), "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.1", ruleName("one"), "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "rule_ids.2", ruleName("two"), "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -7,19 +7,18 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/okta/terraform-provider-okta/sdk"
 )
 
 func TestAccOktaPolicyRulePassword_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := testOktaPolicyRulePassword(ri)
 	updatedConfig := testOktaPolicyRulePasswordUpdated(ri)
 	resourceName := buildResourceFQN(policyRulePassword, ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createRuleCheckDestroy(policyRulePassword),
@@ -49,10 +48,10 @@ func TestAccOktaPolicyRulePassword_crud(t *testing.T) {
 
 // Testing the logic that errors when an invalid priority is provided
 func TestAccOktaPolicyRulePassword_priorityError(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := testOktaPolicyRulePriorityError(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createRuleCheckDestroy(policyRulePassword),
@@ -67,12 +66,12 @@ func TestAccOktaPolicyRulePassword_priorityError(t *testing.T) {
 
 // Testing the successful setting of priority
 func TestAccOktaPolicyRulePassword_priority(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	config := testOktaPolicyRulePriority(ri)
 	resourceName := buildResourceFQN(policyRulePassword, ri)
 	name := buildResourceName(ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createRuleCheckDestroy(policyRulePassword),
@@ -5,14 +5,13 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaPolicyRuleSignon_defaultErrors(t *testing.T) {
-	config := testOktaPolicyRuleSignOnDefaultErrors(acctest.RandInt())
+	config := testOktaPolicyRuleSignOnDefaultErrors(vcrRandInt(t))
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createRuleCheckDestroy(policyRuleSignOn),
@@ -26,7 +25,7 @@ func TestAccOktaPolicyRuleSignon_defaultErrors(t *testing.T) {
 }
 
 func TestAccOktaPolicyRuleSignon_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(policyRuleSignOn)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("basic_updated.tf", ri, t)
@@ -36,7 +35,7 @@ func TestAccOktaPolicyRuleSignon_crud(t *testing.T) {
 	factorSequence := mgr.GetFixtures("factor_sequence.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", policyRuleSignOn)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      createRuleCheckDestroy(policyRuleSignOn),
@@ -77,8 +77,9 @@ var mappingResource = &schema.Resource{
 			Description: "The mapping property key.",
 		},
 		"expression": {
-			Type:     schema.TypeString,
-			Required: true,
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: stringIsOktaExpression,
 		},
 		"push_status": {
 			Type:             schema.TypeString,
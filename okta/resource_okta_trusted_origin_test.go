@@ -5,19 +5,18 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOktaTrustedOrigin_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(trustedOrigin)
 	config := mgr.GetFixtures("okta_trusted_origin.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("okta_trusted_origin_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.testAcc_%d", trustedOrigin, ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckTrustedOriginDestroy,
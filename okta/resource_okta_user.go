@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -58,6 +59,11 @@ func resourceUser() *schema.Resource {
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				// Supporting id and email based imports
@@ -428,9 +434,10 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 	// Only sync when there is opt in, consumers can chose which route they want to take
 	if _, exists := d.GetOk("group_memberships"); exists {
 		groups := convertInterfaceToStringSetNullable(d.Get("group_memberships"))
-		err = assignGroupsToUser(ctx, user.Id, groups, client)
-		if err != nil {
-			return diag.FromErr(err)
+		jobs := userGroupJobs(client, user.Id, groups, nil)
+		results := runSetChangeJobs(ctx, d, m, "group_memberships", d.Get("group_memberships").(*schema.Set), jobs)
+		if diags := jobsDiagnostics(results); diags.HasError() {
+			return diags
 		}
 	}
 
@@ -587,13 +594,10 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		newSet := newGM.(*schema.Set)
 		groupsToAdd := convertInterfaceArrToStringArr(newSet.Difference(oldSet).List())
 		groupsToRemove := convertInterfaceArrToStringArr(oldSet.Difference(newSet).List())
-		err := addUserToGroups(ctx, client, d.Id(), groupsToAdd)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		err = removeUserFromGroups(ctx, client, d.Id(), groupsToRemove)
-		if err != nil {
-			return diag.FromErr(err)
+		jobs := userGroupJobs(client, d.Id(), groupsToAdd, groupsToRemove)
+		results := runSetChangeJobs(ctx, d, m, "group_memberships", newSet, jobs)
+		if diags := jobsDiagnostics(results); diags.HasError() {
+			return diags
 		}
 	}
 
@@ -4,12 +4,11 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccOktaUserAdminRoles_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 
 	mgr := newFixtureManager(userAdminRoles)
 	start := mgr.GetFixtures("basic.tf", ri, t)
@@ -17,7 +16,7 @@ func TestAccOktaUserAdminRoles_crud(t *testing.T) {
 	remove := mgr.GetFixtures("basic_removal.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", userAdminRoles)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
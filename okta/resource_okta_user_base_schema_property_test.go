@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -16,14 +15,14 @@ const (
 )
 
 func TestAccOktaUserBaseSchema_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userBaseSchemaProperty)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updated := mgr.GetFixtures("updated.tf", ri, t)
 	nonDefault := mgr.GetFixtures("non_default_user_type.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.%s", userBaseSchemaProperty, firstNameTestProp)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      nil, // can't delete base properties
@@ -75,13 +74,13 @@ func TestAccOktaUserBaseSchema_crud(t *testing.T) {
 }
 
 func TestAccOktaUserBaseSchemaLogin_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userBaseSchemaProperty)
 	config := mgr.GetFixtures("basic_login.tf", ri, t)
 	updated := mgr.GetFixtures("login_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.%s", userBaseSchemaProperty, loginTestProp)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      nil, // can't delete base properties
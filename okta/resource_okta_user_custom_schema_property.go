@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceUserCustomSchemaProperty() *schema.Resource {
@@ -176,7 +177,7 @@ func alterCustomUserSchema(ctx context.Context, m interface{}, userType, index s
 			}
 			return backoff.Permanent(err)
 		}
-		s, _, err := getOktaClientFromMetadata(m).UserSchema.GetUserSchema(ctx, typeSchemaID)
+		s, _, err := getOktaClientFromMetadata(m).UserSchema.GetUserSchema(transport.SkipCache(ctx), typeSchemaID)
 		if err != nil {
 			return backoff.Permanent(fmt.Errorf("failed to get user custom schema property: %v", err))
 		}
@@ -7,13 +7,12 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceOktaUserSchema_crud(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updated := mgr.GetFixtures("updated.tf", ri, t)
@@ -21,7 +20,7 @@ func TestAccResourceOktaUserSchema_crud(t *testing.T) {
 	nonDefault := mgr.GetFixtures("non_default_user_type.tf", ri, t)
 	resourceName := buildResourceFQN(userSchemaProperty, ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -111,7 +110,7 @@ func TestAccResourceOktaUserSchema_crud(t *testing.T) {
 }
 
 func TestAccResourceOktaUserSchema_array_enum(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
 	mgr := newFixtureManager(userSchemaProperty)
 	config := mgr.GetFixtures("array_string.tf", ri, t)
@@ -119,7 +118,7 @@ func TestAccResourceOktaUserSchema_array_enum(t *testing.T) {
 	arrayEnum := mgr.GetFixtures("array_enum.tf", ri, t)
 	arrayNumber := mgr.GetFixtures("array_number.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -220,10 +219,10 @@ func checkOktaUserSchemasDestroy() resource.TestCheckFunc {
 }
 
 func TestAccResourceOktaUserSchema_array_enum_number(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -311,10 +310,10 @@ func TestAccResourceOktaUserSchema_array_enum_number(t *testing.T) {
 }
 
 func TestAccResourceOktaUserSchema_enum_number(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -398,10 +397,10 @@ func TestAccResourceOktaUserSchema_enum_number(t *testing.T) {
 }
 
 func TestAccResourceOktaUserSchema_array_enum_integer(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -489,10 +488,10 @@ func TestAccResourceOktaUserSchema_array_enum_integer(t *testing.T) {
 }
 
 func TestAccResourceOktaUserSchema_enum_integer(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -578,10 +577,10 @@ func TestAccResourceOktaUserSchema_enum_integer(t *testing.T) {
 func TestAccResourceOktaUserSchema_array_enum_boolean(t *testing.T) {
 	// TODO deal with apparent monolith bug:
 	// "the API returned an error: Array specified in enum field must match const values specified in oneOf field."
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -657,10 +656,10 @@ func TestAccResourceOktaUserSchema_array_enum_boolean(t *testing.T) {
 func TestAccResourceOktaUserSchema_enum_boolean(t *testing.T) {
 	// TODO deal with apparent monolith bug:
 	// "the API returned an error: Array specified in enum field must match const values specified in oneOf field."
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -730,10 +729,10 @@ func TestAccResourceOktaUserSchema_enum_boolean(t *testing.T) {
 }
 
 func TestAccResourceOktaUserSchema_array_enum_string(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
@@ -821,10 +820,10 @@ func TestAccResourceOktaUserSchema_array_enum_string(t *testing.T) {
 }
 
 func TestAccResourceOktaUserSchema_enum_string(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(userSchemaProperty)
 	resourceName := fmt.Sprintf("%s.test", userSchemaProperty)
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaUserSchemasDestroy(),
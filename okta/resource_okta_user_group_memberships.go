@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 func resourceUserGroupMemberships() *schema.Resource {
@@ -40,15 +41,20 @@ func resourceUserGroupMembershipsCreate(ctx context.Context, d *schema.ResourceD
 	userId := d.Get("user_id").(string)
 	groups := convertInterfaceToStringSetNullable(d.Get("groups"))
 	client := getOktaClientFromMetadata(m)
-	err := addUserToGroups(ctx, client, userId, groups)
-	if err != nil {
-		return diag.FromErr(err)
+	jobs := userGroupJobs(client, userId, groups, nil)
+	results := runSetChangeJobs(ctx, d, m, "groups", d.Get("groups").(*schema.Set), jobs)
+	if diags := jobsDiagnostics(results); diags.HasError() {
+		// keep track of the groups the user did get added to
+		if len(diags) < len(results) {
+			d.SetId(userId)
+		}
+		return diags
 	}
 	bOff := backoff.NewExponentialBackOff()
 	bOff.MaxElapsedTime = time.Second * 10
 	bOff.InitialInterval = time.Second
-	err = backoff.Retry(func() error {
-		ok, err := checkIfUserHasGroups(ctx, client, userId, groups)
+	err := backoff.Retry(func() error {
+		ok, err := checkIfUserHasGroups(transport.SkipCache(ctx), client, userId, groups)
 		if err != nil {
 			return backoff.Permanent(err)
 		}
@@ -83,13 +89,12 @@ func resourceUserGroupMembershipsRead(ctx context.Context, d *schema.ResourceDat
 
 func resourceUserGroupMembershipsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	userId := d.Get("user_id").(string)
-	groups := convertInterfaceToStringSetNullable(d.Get("groups"))
+	groups := d.Get("groups").(*schema.Set)
 	client := getOktaClientFromMetadata(m)
-	err := removeUserFromGroups(ctx, client, userId, groups)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
+	jobs := userGroupJobs(client, userId, nil, convertInterfaceToStringSetNullable(groups))
+	// whatever the user fails to be removed from stays in the state
+	results := runSetChangeJobs(ctx, d, m, "groups", schema.NewSet(groups.F, nil), jobs)
+	return jobsDiagnostics(results)
 }
 
 func resourceUserGroupMembershipsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -103,15 +108,9 @@ func resourceUserGroupMembershipsUpdate(ctx context.Context, d *schema.ResourceD
 	groupsToAdd := convertInterfaceArrToStringArr(newSet.Difference(oldSet).List())
 	groupsToRemove := convertInterfaceArrToStringArr(oldSet.Difference(newSet).List())
 
-	err := addUserToGroups(ctx, client, userId, groupsToAdd)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	err = removeUserFromGroups(ctx, client, userId, groupsToRemove)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	return nil
+	jobs := userGroupJobs(client, userId, groupsToAdd, groupsToRemove)
+	results := runSetChangeJobs(ctx, d, m, "groups", newSet, jobs)
+	return jobsDiagnostics(results)
 }
 
 func checkIfUserHasGroups(ctx context.Context, client *okta.Client, userId string, groups []string) (bool, error) {
@@ -10,13 +10,12 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccOktaUser_customProfileAttributes(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("custom_attributes.tf", ri, t)
 	arrayAttrConfig := mgr.GetFixtures("custom_attributes_array.tf", ri, t)
@@ -25,7 +24,7 @@ func TestAccOktaUser_customProfileAttributes(t *testing.T) {
 	resourceName := fmt.Sprintf("%s.test", user)
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -86,14 +85,14 @@ func TestAccOktaUser_customProfileAttributes(t *testing.T) {
 }
 
 func TestAccOktaUser_groupMembership(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("group_assigned.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("group_unassigned.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", user)
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -133,9 +132,9 @@ func TestAccOktaUser_groupMembership(t *testing.T) {
 }
 
 func TestAccOktaUser_invalidCustomProfileAttribute(t *testing.T) {
-	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	rName := strconv.Itoa(vcrRandInt(t))
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -149,7 +148,7 @@ func TestAccOktaUser_invalidCustomProfileAttribute(t *testing.T) {
 }
 
 func TestAccOktaUser_updateAllAttributes(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("staged.tf", ri, t)
 	updatedConfig := mgr.GetFixtures("all_attributes.tf", ri, t)
@@ -157,7 +156,7 @@ func TestAccOktaUser_updateAllAttributes(t *testing.T) {
 	resourceName := fmt.Sprintf("%s.test", user)
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -221,7 +220,7 @@ func TestAccOktaUser_updateAllAttributes(t *testing.T) {
 }
 
 func TestAccOktaUser_updateCredentials(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("basic_with_credentials.tf", ri, t)
 	minimalConfigWithCredentials := mgr.GetFixtures("basic_with_credentials_updated.tf", ri, t)
@@ -229,7 +228,7 @@ func TestAccOktaUser_updateCredentials(t *testing.T) {
 	resourceName := fmt.Sprintf("%s.test", user)
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -273,14 +272,14 @@ func TestAccOktaUser_updateCredentials(t *testing.T) {
 }
 
 func TestAccOktaUser_statusDeprovisioned(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	statusChanged := mgr.GetFixtures("deprovisioned.tf", ri, t)
 	config := mgr.GetFixtures("staged.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", user)
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -303,14 +302,14 @@ func TestAccOktaUser_statusDeprovisioned(t *testing.T) {
 }
 
 func TestAccOktaUserHashedPassword(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("password_hash.tf", ri, t)
 	configUpdated := mgr.GetFixtures("password_hash_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", user)
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -342,11 +341,11 @@ func TestAccOktaUserHashedPassword(t *testing.T) {
 }
 
 func TestAccOktaUser_updateDeprovisioned(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("deprovisioned.tf", ri, t)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -363,7 +362,7 @@ func TestAccOktaUser_updateDeprovisioned(t *testing.T) {
 }
 
 func TestAccOktaUser_loginUpdates(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(user)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updatedLogin := mgr.GetFixtures("login_changed.tf", ri, t)
@@ -372,7 +371,7 @@ func TestAccOktaUser_loginUpdates(t *testing.T) {
 	email := fmt.Sprintf("testAcc-%d@example.com", ri)
 	updatedEmail := fmt.Sprintf("testAccUpdated-%d@example.com", ri)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      testAccCheckUserDestroy,
@@ -52,7 +52,7 @@ func resourceSecurityNotificationEmails() *schema.Resource {
 
 func resourceSecurityNotificationEmailsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Config)
-	emails, err := getSupplementFromMetadata(m).UpdateSecurityNotificationEmails(ctx, buildSecurityNotificationEmails(d), c.orgName, c.domain, c.apiToken, c.client)
+	emails, err := getSupplementFromMetadata(m).UpdateSecurityNotificationEmails(ctx, buildSecurityNotificationEmails(d), c.internalAPI())
 	if err != nil {
 		return diag.Errorf("failed to update security notification emails: %v", err)
 	}
@@ -67,7 +67,7 @@ func resourceSecurityNotificationEmailsCreate(ctx context.Context, d *schema.Res
 
 func resourceSecurityNotificationEmailsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Config)
-	emails, err := getSupplementFromMetadata(m).GetSecurityNotificationEmails(ctx, c.orgName, c.domain, c.apiToken, c.client)
+	emails, err := getSupplementFromMetadata(m).GetSecurityNotificationEmails(ctx, c.internalAPI())
 	if err != nil {
 		return diag.Errorf("failed to get security notification emails: %v", err)
 	}
@@ -82,7 +82,7 @@ func resourceSecurityNotificationEmailsRead(ctx context.Context, d *schema.Resou
 
 func resourceSecurityNotificationEmailsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Config)
-	_, err := getSupplementFromMetadata(m).UpdateSecurityNotificationEmails(ctx, buildSecurityNotificationEmails(d), c.orgName, c.domain, c.apiToken, c.client)
+	_, err := getSupplementFromMetadata(m).UpdateSecurityNotificationEmails(ctx, buildSecurityNotificationEmails(d), c.internalAPI())
 	if err != nil {
 		return diag.Errorf("failed to update security notification emails: %v", err)
 	}
@@ -98,7 +98,7 @@ func resourceSecurityNotificationEmailsDelete(ctx context.Context, d *schema.Res
 		SendEmailForPasswordChangedEnabled:  true,
 		ReportSuspiciousActivityEnabled:     true,
 	}
-	_, err := getSupplementFromMetadata(m).UpdateSecurityNotificationEmails(ctx, emails, c.orgName, c.domain, c.apiToken, c.client)
+	_, err := getSupplementFromMetadata(m).UpdateSecurityNotificationEmails(ctx, emails, c.internalAPI())
 	if err != nil {
 		return diag.Errorf("failed to set default security notification emails: %v", err)
 	}
@@ -6,19 +6,18 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccSecurityNotificationEmails(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := vcrRandInt(t)
 	mgr := newFixtureManager(securityNotificationEmails)
 	config := mgr.GetFixtures("basic.tf", ri, t)
 	updated := mgr.GetFixtures("basic_updated.tf", ri, t)
 	resourceName := fmt.Sprintf("%s.test", securityNotificationEmails)
 
-	resource.Test(t, resource.TestCase{
+	oktaResourceTest(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProvidersFactories,
 		CheckDestroy:      checkOktaSecurityNotificationEmailsDestroy(),
@@ -46,7 +45,7 @@ func checkOktaSecurityNotificationEmailsDestroy() resource.TestCheckFunc {
 				continue
 			}
 			c := testAccProvider.Meta().(*Config)
-			emails, err := getSupplementFromMetadata(testAccProvider.Meta()).GetSecurityNotificationEmails(context.Background(), c.orgName, c.domain, c.apiToken, c.client)
+			emails, err := getSupplementFromMetadata(testAccProvider.Meta()).GetSecurityNotificationEmails(context.Background(), c.internalAPI())
 			if err != nil {
 				return fmt.Errorf("failed to get security notification emails: %v", err)
 			}
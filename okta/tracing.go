@@ -0,0 +1,213 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
+)
+
+const (
+	traceExporterNone = "none"
+	traceExporterOTLP = "otlp"
+	traceExporterFile = "file"
+
+	tracerName = "github.com/okta/terraform-provider-okta"
+)
+
+var noopTracer = trace.NewNoopTracerProvider().Tracer(tracerName)
+
+// configureTracing sets up the tracer of the config for the configured trace
+// exporter. Spans are exported in batches, the remaining ones are flushed when
+// the provider shuts down.
+func (c *Config) configureTracing(ctx context.Context) error {
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch c.traceExporter {
+	case "", traceExporterNone:
+		return nil
+	case traceExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if c.traceOTLPEndpoint != "" {
+			u, err := url.Parse(c.traceOTLPEndpoint)
+			if err != nil || u.Host == "" {
+				return fmt.Errorf("invalid OTLP endpoint %q, expecting a URL like https://collector:4318", c.traceOTLPEndpoint)
+			}
+			opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+			if u.Path != "" && u.Path != "/" {
+				opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+			}
+			if u.Scheme == "http" {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+		}
+		if len(c.traceOTLPHeaders) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(c.traceOTLPHeaders))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case traceExporterFile:
+		var f *os.File
+		f, err = os.OpenFile(c.traceFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open trace file: %v", err)
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return fmt.Errorf("unknown trace exporter %q", c.traceExporter)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %s trace exporter: %v", c.traceExporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String("terraform-provider-okta"),
+			attribute.String("okta.org_name", c.orgName),
+			attribute.String("okta.base_url", c.domain),
+		)),
+	)
+	registerShutdownHook(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			c.logger.Warn(fmt.Sprintf("failed to flush the traces: %v", err))
+		}
+		if closer != nil {
+			_ = closer.Close()
+		}
+	})
+	c.tracer = provider.Tracer(tracerName)
+	c.logger.Info(fmt.Sprintf("tracing with the %s exporter", c.traceExporter))
+	return nil
+}
+
+// startSpan starts a span for an operation of a resource or data source, it is
+// a no-op span if tracing isn't configured.
+func startSpan(ctx context.Context, meta interface{}, name, operation string) (context.Context, trace.Span) {
+	tracer := noopTracer
+	if config, ok := meta.(*Config); ok && config.tracer != nil {
+		tracer = config.tracer
+	}
+	return tracer.Start(ctx, name+" "+operation,
+		trace.WithAttributes(
+			attribute.String("terraform.resource_type", name),
+			attribute.String("terraform.operation", operation),
+		),
+	)
+}
+
+// endSpan ends the span of a resource or data source operation, recording the
+// ID it ended with and the errors it returned.
+func endSpan(span trace.Span, id string, diags diag.Diagnostics) diag.Diagnostics {
+	defer span.End()
+	if !span.IsRecording() {
+		return diags
+	}
+	if id != "" {
+		span.SetAttributes(attribute.String("terraform.id", id))
+	}
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			span.SetStatus(codes.Error, d.Summary)
+			span.AddEvent("error", trace.WithAttributes(
+				attribute.String("summary", d.Summary),
+				attribute.String("detail", d.Detail),
+			))
+		}
+	}
+	return diags
+}
+
+// startFrameworkSpan starts the span of an operation of a framework resource or
+// data source, and passes its resource type on in its context like
+// instrumentResource does for the SDK ones. The framework operations can't be
+// wrapped, so they call it and end the span with endFrameworkSpan.
+func startFrameworkSpan(ctx context.Context, config *Config, name, operation string) (context.Context, trace.Span) {
+	var meta interface{}
+	if config != nil {
+		meta = config
+	}
+	return startSpan(transport.WithResourceType(ctx, name), meta, name, operation)
+}
+
+// endFrameworkSpan ends the span of a framework resource or data source
+// operation like endSpan.
+func endFrameworkSpan(span trace.Span, id string, diags fwdiag.Diagnostics) {
+	var errs diag.Diagnostics
+	for _, d := range diags {
+		if d.Severity() == fwdiag.SeverityError {
+			errs = append(errs, diag.Diagnostic{Severity: diag.Error, Summary: d.Summary(), Detail: d.Detail()})
+		}
+	}
+	endSpan(span, id, errs)
+}
+
+// instrumentResource wraps the CRUD functions of the resource, so each of them
+// runs in its own span and passes its resource type on in its context for the
+// API telemetry and HTTP spans.
+func instrumentResource(name string, r *schema.Resource) {
+	if f := r.CreateContext; f != nil {
+		r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ctx, span := startSpan(transport.WithResourceType(ctx, name), m, name, "create")
+			return endSpan(span, d.Id(), f(ctx, d, m))
+		}
+	}
+	if f := r.ReadContext; f != nil {
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ctx, span := startSpan(transport.WithResourceType(ctx, name), m, name, "read")
+			return endSpan(span, d.Id(), f(ctx, d, m))
+		}
+	}
+	if f := r.UpdateContext; f != nil {
+		r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ctx, span := startSpan(transport.WithResourceType(ctx, name), m, name, "update")
+			return endSpan(span, d.Id(), f(ctx, d, m))
+		}
+	}
+	if f := r.DeleteContext; f != nil {
+		r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ctx, span := startSpan(transport.WithResourceType(ctx, name), m, name, "delete")
+			return endSpan(span, d.Id(), f(ctx, d, m))
+		}
+	}
+	if f := r.CustomizeDiff; f != nil {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			return f(transport.WithResourceType(ctx, name), d, m)
+		}
+	}
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		f := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			ctx, span := startSpan(transport.WithResourceType(ctx, name), m, name, "import")
+			result, err := f(ctx, d, m)
+			var diags diag.Diagnostics
+			if err != nil {
+				diags = diag.FromErr(err)
+			}
+			endSpan(span, d.Id(), diags)
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package okta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/okta/terraform-provider-okta/okta/internal/apimutex"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
+)
+
+func TestInstrumentResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	config := &Config{tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)}
+	apiMutex, _ := apimutex.NewAPIMutex(100)
+	client := &http.Client{Transport: transport.NewTracingTransport(http.DefaultTransport, config.tracer, apiMutex)}
+	call := func(ctx context.Context, method string) diag.Diagnostics {
+		req, _ := http.NewRequestWithContext(ctx, method, server.URL+"/api/v1/groups", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return diag.Errorf("request failed: %s", resp.Status)
+		}
+		return nil
+	}
+
+	r := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			d.SetId("00g1abcdefghijklmnop")
+			return call(ctx, http.MethodPost)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return call(ctx, http.MethodDelete)
+		},
+		Schema: map[string]*schema.Schema{},
+	}
+	instrumentResource(group, r)
+
+	d := r.TestResourceData()
+	if diags := r.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("create had error %+v", diags)
+	}
+	if diags := r.DeleteContext(context.Background(), d, config); !diags.HasError() {
+		t.Fatalf("delete should have failed")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("expected 4 spans, got %d", len(spans))
+	}
+	expected := []struct {
+		name   string
+		parent int
+		status codes.Code
+	}{
+		{name: "HTTP POST", parent: 1, status: codes.Unset},
+		{name: "okta_group create", parent: -1, status: codes.Unset},
+		{name: "HTTP DELETE", parent: 3, status: codes.Error},
+		{name: "okta_group delete", parent: -1, status: codes.Error},
+	}
+	for i, e := range expected {
+		span := spans[i]
+		if span.Name() != e.name {
+			t.Errorf("expected span %d to be %q, got %q", i, e.name, span.Name())
+		}
+		if span.Status().Code != e.status {
+			t.Errorf("expected span %q status %v, got %v", span.Name(), e.status, span.Status().Code)
+		}
+		if e.parent >= 0 && span.Parent().SpanID() != spans[e.parent].SpanContext().SpanID() {
+			t.Errorf("expected span %q to be a child of %q", span.Name(), spans[e.parent].Name())
+		}
+	}
+	for _, attr := range spans[1].Attributes() {
+		if attr.Key == "terraform.id" && attr.Value.AsString() != "00g1abcdefghijklmnop" {
+			t.Errorf("expected the create span to record the ID, got %q", attr.Value.AsString())
+		}
+	}
+}
+
+func TestInstrumentResourceWithoutTracing(t *testing.T) {
+	r := &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if resourceType := transport.ResourceType(ctx); resourceType != "data."+group {
+				t.Errorf("expected the resource type in the context, got %q", resourceType)
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	}
+	instrumentResource("data."+group, r)
+	if diags := r.ReadContext(context.Background(), r.TestResourceData(), &Config{}); diags.HasError() {
+		t.Fatalf("read had error %+v", diags)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
 	"github.com/okta/okta-sdk-golang/v2/okta/query"
+	"github.com/okta/terraform-provider-okta/okta/internal/transport"
 )
 
 const (
@@ -199,16 +200,6 @@ func assignAdminRolesToUser(ctx context.Context, userID string, roles []string,
 	return nil
 }
 
-func assignGroupsToUser(ctx context.Context, userID string, groups []string, c *okta.Client) error {
-	for _, group := range groups {
-		_, err := c.Group.AddUserToGroup(ctx, group, userID)
-		if err != nil {
-			return fmt.Errorf("failed to assign group '%s' to user '%s': %w", group, userID, err)
-		}
-	}
-	return nil
-}
-
 func populateUserProfile(d *schema.ResourceData) *okta.UserProfile {
 	profile := okta.UserProfile{}
 
@@ -449,8 +440,11 @@ func updateUserStatus(ctx context.Context, uid, desiredStatus string, c *okta.Cl
 }
 
 // need to wait for user.TransitioningToStatus field to be empty before allowing Terraform to continue
-// so the proper current status gets set in the state during the Read operation after a Status update
+// so the proper current status gets set in the state during the Read operation after a Status update.
+// It gives up when the context is done, i.e. when the timeout of the operation is reached. The user is
+// polled from the API, not the cache.
 func waitForStatusTransition(ctx context.Context, u string, c *okta.Client) error {
+	ctx = transport.SkipCache(ctx)
 	user, _, err := c.User.GetUser(ctx, u)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %v", err)
@@ -461,7 +455,11 @@ func waitForStatusTransition(ctx context.Context, u string, c *okta.Client) erro
 		}
 
 		log.Printf("[INFO] Transitioning to status = %v; waiting for 5 more seconds...", user.TransitioningToStatus)
-		time.Sleep(5 * time.Second)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for user to transition to status %s: %w", user.TransitioningToStatus, ctx.Err())
+		case <-time.After(5 * time.Second):
+		}
 		user, _, err = c.User.GetUser(ctx, u)
 		if err != nil {
 			return fmt.Errorf("failed to get user: %v", err)
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/okta/okta-sdk-golang/v2/okta"
@@ -103,3 +105,69 @@ func TestUserSetAllGroups(t *testing.T) {
 func TestUserSetGroups(t *testing.T) {
 	testUserGroupFetchesAllPages(t, setGroupUserMemberships)
 }
+
+func TestWaitForStatusTransitionHonorsDeadline(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	userID := srv.Put("/users", map[string]interface{}{
+		"status":                "STAGED",
+		"transitioningToStatus": "ACTIVE",
+		"profile":               map[string]interface{}{"login": "testAcc@example.com"},
+	})
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = waitForStatusTransition(ctx, userID, config.oktaClient)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the wait to stop at the deadline, it took %s", elapsed)
+	}
+
+	delete(srv.Get("/users/"+userID), "transitioningToStatus")
+	if err := waitForStatusTransition(context.Background(), userID, config.oktaClient); err != nil {
+		t.Fatalf("expected the wait to end once the transition is over, got %v", err)
+	}
+}
+
+func TestWaitForStatusTransitionSkipsReadCache(t *testing.T) {
+	srv := newFakeOktaServer(t)
+	userID := srv.Put("/users", map[string]interface{}{
+		"status":                "STAGED",
+		"transitioningToStatus": "ACTIVE",
+		"profile":               map[string]interface{}{"login": "testAcc@example.com"},
+	})
+	config, err := oktaConfig()
+	if err != nil {
+		t.Fatalf("failed to configure the provider against the fake server: %v", err)
+	}
+	config.readCache = true
+	if err := config.loadAndValidate(context.Background()); err != nil {
+		t.Fatalf("failed to configure the provider with the read cache: %v", err)
+	}
+
+	// the response with the transition in progress is cached
+	if _, _, err := config.oktaClient.User.GetUser(context.Background(), userID); err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+	delete(srv.Get("/users/"+userID), "transitioningToStatus")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := waitForStatusTransition(ctx, userID, config.oktaClient); err != nil {
+		t.Fatalf("expected the wait to see the transition is over rather than the cached user, got %v", err)
+	}
+	var gets int
+	for _, r := range srv.Requests() {
+		if r == "GET /api/v1/users/"+userID {
+			gets++
+		}
+	}
+	if gets != 2 {
+		t.Fatalf("expected the user to be requested from the API twice, got %d", gets)
+	}
+}
@@ -157,6 +157,18 @@ func convertInterfaceToStringArrNullable(purportedList interface{}) []string {
 	return arr
 }
 
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func createNestedResourceImporter(fields []string) *schema.ResourceImporter {
 	return createCustomNestedResourceImporter(fields, fmt.Sprintf("Expecting the following format %s", strings.Join(fields, "/")))
 }
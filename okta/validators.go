@@ -1,15 +1,18 @@
 package okta
 
 import (
+	"fmt"
 	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/okta/terraform-provider-okta/okta/internal/expression"
 )
 
 func intBetween(min, max int) schema.SchemaValidateDiagFunc {
@@ -82,6 +85,22 @@ func elemInSlice(s interface{}) schema.SchemaValidateDiagFunc {
 	}
 }
 
+func mapKeysInSlice(keys []string) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, k cty.Path) diag.Diagnostics {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			return diag.Errorf("expected type of %s to be map", k)
+		}
+		var diags diag.Diagnostics
+		for key := range m {
+			if !contains(keys, key) {
+				diags = append(diags, diag.FromErr(k.NewErrorf("expected keys to be one of '%v', got '%s'", strings.Join(keys, "', '"), key))...)
+			}
+		}
+		return diags
+	}
+}
+
 func logoValid() schema.SchemaValidateDiagFunc {
 	return func(i interface{}, k cty.Path) diag.Diagnostics {
 		v, ok := i.(string)
@@ -141,6 +160,17 @@ func stringIsJSON(i interface{}, k cty.Path) diag.Diagnostics {
 	return nil
 }
 
+func stringIsRegex(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %s to be string", k)
+	}
+	if _, err := regexp.Compile(v); err != nil {
+		return diag.Errorf("%q contains an invalid regular expression: %s", k, err)
+	}
+	return nil
+}
+
 func stringLenBetween(min, max int) schema.SchemaValidateDiagFunc {
 	return func(i interface{}, k cty.Path) diag.Diagnostics {
 		v, ok := i.(string)
@@ -198,3 +228,61 @@ func stringIsVersion(i interface{}, k cty.Path) diag.Diagnostics {
 func stringIsPeriod(i interface{}, k cty.Path) diag.Diagnostics {
 	return stringMatches(i, k, periodRegex, "period")
 }
+
+func stringIsTimestamp(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %s to be string", k)
+	}
+	if _, err := time.Parse(time.RFC3339, v); err != nil {
+		return diag.Errorf("%s field is not a valid RFC 3339 timestamp, e.g. 2022-08-01T00:00:00Z: %v", k, err)
+	}
+	return nil
+}
+
+// stringIsOktaExpression checks the syntax, functions and base attributes of an
+// Okta Expression Language expression. An unknown variable or an attribute
+// which is likely a typo is a warning, it can be one the provider doesn't know
+// about.
+func stringIsOktaExpression(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %s to be string", k)
+	}
+	var diags diag.Diagnostics
+	for _, err := range expression.Check(v, expression.DefaultEnv()) {
+		severity := diag.Error
+		if err.Warning {
+			severity = diag.Warning
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      severity,
+			Summary:       "Invalid Okta expression",
+			Detail:        expressionProblem(v, err),
+			AttributePath: k,
+		})
+	}
+	return diags
+}
+
+// oktaExpressionError is stringIsOktaExpression for CustomizeDiff functions,
+// which can't report warnings, it returns the first error of the expression.
+func oktaExpressionError(key, v string) error {
+	for _, err := range expression.Check(v, expression.DefaultEnv()) {
+		if !err.Warning {
+			return fmt.Errorf("invalid Okta expression in %s: %s", key, expressionProblem(v, err))
+		}
+	}
+	return nil
+}
+
+// expressionProblem is the message of the error followed by the line of the
+// expression with a caret under the position of the error.
+func expressionProblem(v string, err *expression.Error) string {
+	start := strings.LastIndex(v[:err.Pos], "\n") + 1
+	line := v[start:]
+	if end := strings.Index(line, "\n"); end >= 0 {
+		line = line[:end]
+	}
+	return fmt.Sprintf("%s\n\n  %s\n  %s^", err, line, strings.Repeat(" ", err.Pos-start))
+}
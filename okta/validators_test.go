@@ -0,0 +1,44 @@
+package okta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestStringIsOktaExpression(t *testing.T) {
+	path := cty.GetAttrPath("expression_value")
+	if diags := stringIsOktaExpression(`String.startsWith(user.firstName, "andy")`, path); len(diags) != 0 {
+		t.Fatalf("expected a valid expression, got %v", diags)
+	}
+
+	diags := stringIsOktaExpression("user.firstName ==\n  String.toLowerCase(user.lastName", path)
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	expected := "expected \",\" or \")\", got end of expression at position 53\n\n    String.toLowerCase(user.lastName\n                                    ^"
+	if diags[0].Detail != expected {
+		t.Errorf("expected detail %q, got %q", expected, diags[0].Detail)
+	}
+
+	diags = stringIsOktaExpression("user.fristName", path)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected one warning, got %v", diags)
+	}
+	if err := oktaExpressionError("value", "user.fristName"); err != nil {
+		t.Errorf("expected warnings to be ignored, got %v", err)
+	}
+	// an unknown variable doesn't fail the plan
+	diags = stringIsOktaExpression("cool", path)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected one warning, got %v", diags)
+	}
+	if err := oktaExpressionError("value", "cool"); err != nil {
+		t.Errorf("expected an unknown variable to be ignored, got %v", err)
+	}
+	if err := oktaExpressionError("value", "String.len()"); err == nil || !strings.Contains(err.Error(), "String.len takes 1 argument") {
+		t.Errorf("expected a wrong number of arguments error, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+)
+
+// ClientRoleAssignment is an admin role assigned to an OAuth client, a standard
+// role of Type, or a custom Role on a ResourceSet when Type is CUSTOM.
+type ClientRoleAssignment struct {
+	ID             string `json:"id,omitempty"`
+	Type           string `json:"type"`
+	Role           string `json:"role,omitempty"`
+	ResourceSet    string `json:"resource-set,omitempty"`
+	Label          string `json:"label,omitempty"`
+	Status         string `json:"status,omitempty"`
+	AssignmentType string `json:"assignmentType,omitempty"`
+}
+
+// ListClientRoleAssignments gets the roles assigned to OAuth client
+func (m *APISupplement) ListClientRoleAssignments(ctx context.Context, clientID string) ([]*ClientRoleAssignment, *okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles", clientID)
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var assignments []*ClientRoleAssignment
+	resp, err := m.RequestExecutor.Do(ctx, req, &assignments)
+	if err != nil {
+		return nil, resp, err
+	}
+	return assignments, resp, nil
+}
+
+// GetClientRoleAssignment gets role assigned to OAuth client by ID
+func (m *APISupplement) GetClientRoleAssignment(ctx context.Context, clientID, roleAssignmentID string) (*ClientRoleAssignment, *okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles/%s", clientID, roleAssignmentID)
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var assignment *ClientRoleAssignment
+	resp, err := m.RequestExecutor.Do(ctx, req, &assignment)
+	if err != nil {
+		return nil, resp, err
+	}
+	return assignment, resp, nil
+}
+
+// CreateClientRoleAssignment assigns role to OAuth client
+func (m *APISupplement) CreateClientRoleAssignment(ctx context.Context, clientID string, body ClientRoleAssignment) (*ClientRoleAssignment, *okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles", clientID)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var assignment *ClientRoleAssignment
+	resp, err := m.RequestExecutor.Do(ctx, req, &assignment)
+	if err != nil {
+		return nil, resp, err
+	}
+	return assignment, resp, nil
+}
+
+// DeleteClientRoleAssignment unassigns role from OAuth client
+func (m *APISupplement) DeleteClientRoleAssignment(ctx context.Context, clientID, roleAssignmentID string) (*okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles/%s", clientID, roleAssignmentID)
+	req, err := m.RequestExecutor.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
+
+// ListClientRoleAppTargets gets the apps the APP_ADMIN role assigned to OAuth
+// client is limited to. The targets with an ID are app instances, the others
+// all the instances of the app.
+func (m *APISupplement) ListClientRoleAppTargets(ctx context.Context, clientID, roleAssignmentID string, qp *query.Params) ([]*okta.CatalogApplication, *okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles/%s/targets/catalog/apps", clientID, roleAssignmentID)
+	if qp != nil {
+		url += qp.String()
+	}
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var apps []*okta.CatalogApplication
+	resp, err := m.RequestExecutor.Do(ctx, req, &apps)
+	if err != nil {
+		return nil, resp, err
+	}
+	return apps, resp, nil
+}
+
+// AddClientRoleAppTarget limits the role assigned to OAuth client to the
+// instances of the app, to a single instance when appID isn't empty.
+func (m *APISupplement) AddClientRoleAppTarget(ctx context.Context, clientID, roleAssignmentID, appName, appID string) (*okta.Response, error) {
+	return m.changeClientRoleTarget(ctx, http.MethodPut, clientID, roleAssignmentID, clientRoleAppTargetPath(appName, appID))
+}
+
+// RemoveClientRoleAppTarget removes the app or app instance from the targets
+// of the role assigned to OAuth client
+func (m *APISupplement) RemoveClientRoleAppTarget(ctx context.Context, clientID, roleAssignmentID, appName, appID string) (*okta.Response, error) {
+	return m.changeClientRoleTarget(ctx, http.MethodDelete, clientID, roleAssignmentID, clientRoleAppTargetPath(appName, appID))
+}
+
+// ListClientRoleGroupTargets gets the groups the role assigned to OAuth client
+// is limited to
+func (m *APISupplement) ListClientRoleGroupTargets(ctx context.Context, clientID, roleAssignmentID string, qp *query.Params) ([]*okta.Group, *okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles/%s/targets/groups", clientID, roleAssignmentID)
+	if qp != nil {
+		url += qp.String()
+	}
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var groups []*okta.Group
+	resp, err := m.RequestExecutor.Do(ctx, req, &groups)
+	if err != nil {
+		return nil, resp, err
+	}
+	return groups, resp, nil
+}
+
+// AddClientRoleGroupTarget limits the role assigned to OAuth client to the
+// group
+func (m *APISupplement) AddClientRoleGroupTarget(ctx context.Context, clientID, roleAssignmentID, groupID string) (*okta.Response, error) {
+	return m.changeClientRoleTarget(ctx, http.MethodPut, clientID, roleAssignmentID, "groups/"+groupID)
+}
+
+// RemoveClientRoleGroupTarget removes the group from the targets of the role
+// assigned to OAuth client
+func (m *APISupplement) RemoveClientRoleGroupTarget(ctx context.Context, clientID, roleAssignmentID, groupID string) (*okta.Response, error) {
+	return m.changeClientRoleTarget(ctx, http.MethodDelete, clientID, roleAssignmentID, "groups/"+groupID)
+}
+
+func (m *APISupplement) changeClientRoleTarget(ctx context.Context, method, clientID, roleAssignmentID, target string) (*okta.Response, error) {
+	url := fmt.Sprintf("/oauth2/v1/clients/%s/roles/%s/targets/%s", clientID, roleAssignmentID, target)
+	req, err := m.RequestExecutor.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
+
+func clientRoleAppTargetPath(appName, appID string) string {
+	if appID == "" {
+		return "catalog/apps/" + appName
+	}
+	return fmt.Sprintf("catalog/apps/%s/%s", appName, appID)
+}
@@ -4,18 +4,50 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/okta/okta-sdk-golang/v2/okta"
 )
 
+// AccessPolicyRule is okta.AccessPolicyRule with the device assurance policies
+// in the device condition, which the okta SDK doesn't have.
+type AccessPolicyRule struct {
+	Id          string                        `json:"id,omitempty"`
+	Type        string                        `json:"type,omitempty"`
+	Name        string                        `json:"name,omitempty"`
+	Status      string                        `json:"status,omitempty"`
+	Priority    int64                         `json:"priority,omitempty"`
+	System      *bool                         `json:"system,omitempty"`
+	Created     *time.Time                    `json:"created,omitempty"`
+	LastUpdated *time.Time                    `json:"lastUpdated,omitempty"`
+	Conditions  *AccessPolicyRuleConditions   `json:"conditions,omitempty"`
+	Actions     *okta.AccessPolicyRuleActions `json:"actions,omitempty"`
+}
+
+type AccessPolicyRuleConditions struct {
+	okta.AccessPolicyRuleConditions
+	Device *DeviceAccessPolicyRuleCondition `json:"device,omitempty"`
+}
+
+type DeviceAccessPolicyRuleCondition struct {
+	okta.DeviceAccessPolicyRuleCondition
+	Assurance *DeviceAssurancePolicyRuleCondition `json:"assurance,omitempty"`
+}
+
+// DeviceAssurancePolicyRuleCondition is the IDs of the device assurance
+// policies, a device matches the condition when it meets one of them.
+type DeviceAssurancePolicyRuleCondition struct {
+	Include []string `json:"include,omitempty"`
+}
+
 // CreateAppSignOnPolicyRule creates a policy rule.
-func (m *APISupplement) CreateAppSignOnPolicyRule(ctx context.Context, policyID string, body okta.AccessPolicyRule) (*okta.AccessPolicyRule, *okta.Response, error) {
+func (m *APISupplement) CreateAppSignOnPolicyRule(ctx context.Context, policyID string, body AccessPolicyRule) (*AccessPolicyRule, *okta.Response, error) {
 	url := fmt.Sprintf("/api/v1/policies/%v/rules", policyID)
 	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, body)
 	if err != nil {
 		return nil, nil, err
 	}
-	var appSignOnPolicyRule *okta.AccessPolicyRule
+	var appSignOnPolicyRule *AccessPolicyRule
 	resp, err := m.RequestExecutor.Do(ctx, req, &appSignOnPolicyRule)
 	if err != nil {
 		return nil, resp, err
@@ -24,13 +56,13 @@ func (m *APISupplement) CreateAppSignOnPolicyRule(ctx context.Context, policyID
 }
 
 // GetAppSignOnPolicyRule gets a policy rule.
-func (m *APISupplement) GetAppSignOnPolicyRule(ctx context.Context, policyID, ruleId string) (*okta.AccessPolicyRule, *okta.Response, error) {
+func (m *APISupplement) GetAppSignOnPolicyRule(ctx context.Context, policyID, ruleId string) (*AccessPolicyRule, *okta.Response, error) {
 	url := fmt.Sprintf("/api/v1/policies/%v/rules/%v", policyID, ruleId)
 	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
-	var appSignOnPolicyRule *okta.AccessPolicyRule
+	var appSignOnPolicyRule *AccessPolicyRule
 	resp, err := m.RequestExecutor.Do(ctx, req, &appSignOnPolicyRule)
 	if err != nil {
 		return nil, resp, err
@@ -39,13 +71,13 @@ func (m *APISupplement) GetAppSignOnPolicyRule(ctx context.Context, policyID, ru
 }
 
 // UpdateAppSignOnPolicyRule updates a policy rule.
-func (m *APISupplement) UpdateAppSignOnPolicyRule(ctx context.Context, policyID, ruleId string, body okta.AccessPolicyRule) (*okta.AccessPolicyRule, *okta.Response, error) {
+func (m *APISupplement) UpdateAppSignOnPolicyRule(ctx context.Context, policyID, ruleId string, body AccessPolicyRule) (*AccessPolicyRule, *okta.Response, error) {
 	url := fmt.Sprintf("/api/v1/policies/%v/rules/%v", policyID, ruleId)
 	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPut, url, body)
 	if err != nil {
 		return nil, nil, err
 	}
-	var appSignOnPolicyRule *okta.AccessPolicyRule
+	var appSignOnPolicyRule *AccessPolicyRule
 	resp, err := m.RequestExecutor.Do(ctx, req, &appSignOnPolicyRule)
 	if err != nil {
 		return nil, resp, err
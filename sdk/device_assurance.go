@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+)
+
+const (
+	DeviceAssurancePlatformAndroid = "ANDROID"
+	DeviceAssurancePlatformIOS     = "IOS"
+	DeviceAssurancePlatformMacOS   = "MACOS"
+	DeviceAssurancePlatformWindows = "WINDOWS"
+)
+
+// DeviceAssurance is a device assurance policy of an Identity Engine org, the
+// requirements of the devices of a platform. Jailbreak is only for ANDROID and
+// IOS, DiskEncryptionType and SecureHardwarePresent for every platform but IOS.
+type DeviceAssurance struct {
+	ID                    string                             `json:"id,omitempty"`
+	Name                  string                             `json:"name"`
+	Platform              string                             `json:"platform"`
+	OSVersion             *DeviceAssuranceOSVersion          `json:"osVersion,omitempty"`
+	DiskEncryptionType    *DeviceAssuranceDiskEncryptionType `json:"diskEncryptionType,omitempty"`
+	ScreenLockType        *DeviceAssuranceScreenLockType     `json:"screenLockType,omitempty"`
+	SecureHardwarePresent *bool                              `json:"secureHardwarePresent,omitempty"`
+	Jailbreak             *bool                              `json:"jailbreak,omitempty"`
+	CreatedDate           *time.Time                         `json:"createdDate,omitempty"`
+	LastUpdate            *time.Time                         `json:"lastUpdate,omitempty"`
+}
+
+type DeviceAssuranceOSVersion struct {
+	Minimum string `json:"minimum,omitempty"`
+}
+
+type DeviceAssuranceDiskEncryptionType struct {
+	Include []string `json:"include"`
+}
+
+type DeviceAssuranceScreenLockType struct {
+	Include []string `json:"include"`
+}
+
+// ListDeviceAssurances gets the device assurance policies
+func (m *APISupplement) ListDeviceAssurances(ctx context.Context, qp *query.Params) ([]*DeviceAssurance, *okta.Response, error) {
+	url := "/api/v1/device-assurances"
+	if qp != nil {
+		url += qp.String()
+	}
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deviceAssurances []*DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &deviceAssurances)
+	if err != nil {
+		return nil, resp, err
+	}
+	return deviceAssurances, resp, nil
+}
+
+// GetDeviceAssurance gets device assurance policy by ID
+func (m *APISupplement) GetDeviceAssurance(ctx context.Context, id string) (*DeviceAssurance, *okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/device-assurances/%s", id)
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deviceAssurance *DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &deviceAssurance)
+	if err != nil {
+		return nil, resp, err
+	}
+	return deviceAssurance, resp, nil
+}
+
+// CreateDeviceAssurance creates device assurance policy
+func (m *APISupplement) CreateDeviceAssurance(ctx context.Context, body DeviceAssurance) (*DeviceAssurance, *okta.Response, error) {
+	url := "/api/v1/device-assurances"
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deviceAssurance *DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &deviceAssurance)
+	if err != nil {
+		return nil, resp, err
+	}
+	return deviceAssurance, resp, nil
+}
+
+// UpdateDeviceAssurance replaces the requirements of device assurance policy,
+// its platform can't be changed
+func (m *APISupplement) UpdateDeviceAssurance(ctx context.Context, id string, body DeviceAssurance) (*DeviceAssurance, *okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/device-assurances/%s", id)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPut, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deviceAssurance *DeviceAssurance
+	resp, err := m.RequestExecutor.Do(ctx, req, &deviceAssurance)
+	if err != nil {
+		return nil, resp, err
+	}
+	return deviceAssurance, resp, nil
+}
+
+// DeleteDeviceAssurance deletes device assurance policy by ID, it can't be
+// deleted while an app sign-on policy rule uses it
+func (m *APISupplement) DeleteDeviceAssurance(ctx context.Context, id string) (*okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/device-assurances/%s", id)
+	req, err := m.RequestExecutor.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
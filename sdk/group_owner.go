@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+)
+
+const (
+	GroupOwnerTypeUser  = "USER"
+	GroupOwnerTypeGroup = "GROUP"
+)
+
+// GroupOwner is a user or a group owning a group, the owners of a group can
+// manage its members without being admins.
+type GroupOwner struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	DisplayName string `json:"displayName,omitempty"`
+	OriginID    string `json:"originId,omitempty"`
+	OriginType  string `json:"originType,omitempty"`
+	Resolved    *bool  `json:"resolved,omitempty"`
+	LastUpdated string `json:"lastUpdated,omitempty"`
+}
+
+// ListGroupOwners gets the owners of group
+func (m *APISupplement) ListGroupOwners(ctx context.Context, groupID string, qp *query.Params) ([]*GroupOwner, *okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/groups/%s/owners", groupID)
+	if qp != nil {
+		url += qp.String()
+	}
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var owners []*GroupOwner
+	resp, err := m.RequestExecutor.Do(ctx, req, &owners)
+	if err != nil {
+		return nil, resp, err
+	}
+	return owners, resp, nil
+}
+
+// CreateGroupOwner assigns a user or a group as owner of group
+func (m *APISupplement) CreateGroupOwner(ctx context.Context, groupID string, body GroupOwner) (*GroupOwner, *okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/groups/%s/owners", groupID)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var owner *GroupOwner
+	resp, err := m.RequestExecutor.Do(ctx, req, &owner)
+	if err != nil {
+		return nil, resp, err
+	}
+	return owner, resp, nil
+}
+
+// DeleteGroupOwner unassigns owner of group
+func (m *APISupplement) DeleteGroupOwner(ctx context.Context, groupID, ownerID string) (*okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/groups/%s/owners/%s", groupID, ownerID)
+	req, err := m.RequestExecutor.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
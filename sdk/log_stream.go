@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+)
+
+const (
+	LogStreamTypeAWSEventBridge = "aws_eventbridge"
+	LogStreamTypeSplunkCloud    = "splunk_cloud_logstreaming"
+)
+
+type LogStream struct {
+	ID          string             `json:"id,omitempty"`
+	Name        string             `json:"name"`
+	Type        string             `json:"type"`
+	Status      string             `json:"status,omitempty"`
+	Settings    *LogStreamSettings `json:"settings,omitempty"`
+	Created     string             `json:"created,omitempty"`
+	LastUpdated string             `json:"lastUpdated,omitempty"`
+}
+
+// LogStreamSettings are the settings of the aws_eventbridge log streams, with
+// AccountID, EventSourceName and Region, or of the splunk_cloud_logstreaming
+// ones, with Edition, Host and Token. The token is never returned by the API.
+type LogStreamSettings struct {
+	AccountID       string `json:"accountId,omitempty"`
+	EventSourceName string `json:"eventSourceName,omitempty"`
+	Region          string `json:"region,omitempty"`
+	Edition         string `json:"edition,omitempty"`
+	Host            string `json:"host,omitempty"`
+	Token           string `json:"token,omitempty"`
+}
+
+// ListLogStreams gets the log streams matching the query params, e.g. a
+// filter on their type or status
+func (m *APISupplement) ListLogStreams(ctx context.Context, qp *query.Params) ([]*LogStream, *okta.Response, error) {
+	url := "/api/v1/logStreams"
+	if qp != nil {
+		url += qp.String()
+	}
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var logStreams []*LogStream
+	resp, err := m.RequestExecutor.Do(ctx, req, &logStreams)
+	if err != nil {
+		return nil, resp, err
+	}
+	return logStreams, resp, nil
+}
+
+// GetLogStream gets log stream by ID
+func (m *APISupplement) GetLogStream(ctx context.Context, id string) (*LogStream, *okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/logStreams/%s", id)
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var logStream *LogStream
+	resp, err := m.RequestExecutor.Do(ctx, req, &logStream)
+	if err != nil {
+		return nil, resp, err
+	}
+	return logStream, resp, nil
+}
+
+// CreateLogStream creates log stream, it is active once created
+func (m *APISupplement) CreateLogStream(ctx context.Context, body LogStream) (*LogStream, *okta.Response, error) {
+	url := "/api/v1/logStreams"
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var logStream *LogStream
+	resp, err := m.RequestExecutor.Do(ctx, req, &logStream)
+	if err != nil {
+		return nil, resp, err
+	}
+	return logStream, resp, nil
+}
+
+// UpdateLogStream replaces the name and settings of log stream
+func (m *APISupplement) UpdateLogStream(ctx context.Context, id string, body LogStream) (*LogStream, *okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/logStreams/%s", id)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPut, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	var logStream *LogStream
+	resp, err := m.RequestExecutor.Do(ctx, req, &logStream)
+	if err != nil {
+		return nil, resp, err
+	}
+	return logStream, resp, nil
+}
+
+// DeleteLogStream deletes log stream by ID
+func (m *APISupplement) DeleteLogStream(ctx context.Context, id string) (*okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/logStreams/%s", id)
+	req, err := m.RequestExecutor.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
+
+func (m *APISupplement) ActivateLogStream(ctx context.Context, id string) (*okta.Response, error) {
+	return m.changeLogStreamLifecycle(ctx, id, "activate")
+}
+
+func (m *APISupplement) DeactivateLogStream(ctx context.Context, id string) (*okta.Response, error) {
+	return m.changeLogStreamLifecycle(ctx, id, "deactivate")
+}
+
+func (m *APISupplement) changeLogStreamLifecycle(ctx context.Context, id, action string) (*okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/logStreams/%s/lifecycle/%s", id, action)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
@@ -132,3 +132,24 @@ func (m *APISupplement) UpdatePolicyRule(ctx context.Context, policyID, ruleId s
 	}
 	return policyRule, resp, nil
 }
+
+// UpdatePolicyRulePriority updates the priority of a policy rule of any type,
+// the rule is written back as it was read so none of its fields are lost.
+func (m *APISupplement) UpdatePolicyRulePriority(ctx context.Context, policyID, ruleId string, priority int64) (*okta.Response, error) {
+	url := fmt.Sprintf("/api/v1/policies/%v/rules/%v", policyID, ruleId)
+	req, err := m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	var policyRule map[string]interface{}
+	resp, err := m.RequestExecutor.Do(ctx, req, &policyRule)
+	if err != nil {
+		return resp, err
+	}
+	policyRule["priority"] = priority
+	req, err = m.RequestExecutor.WithAccept("application/json").WithContentType("application/json").NewRequest(http.MethodPut, url, policyRule)
+	if err != nil {
+		return nil, err
+	}
+	return m.RequestExecutor.Do(ctx, req, nil)
+}
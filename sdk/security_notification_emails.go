@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 )
@@ -17,8 +18,19 @@ type SecurityNotificationEmails struct {
 	ReportSuspiciousActivityEnabled     bool `json:"reportSuspiciousActivityEnabled"`
 }
 
-func (m *APISupplement) UpdateSecurityNotificationEmails(ctx context.Context, body SecurityNotificationEmails, orgName, domain, token string, client *http.Client) (*SecurityNotificationEmails, error) {
-	url := fmt.Sprintf("https://%s-admin.%s/api/internal/org/settings/security-notification-settings", orgName, domain)
+// InternalAPI is how to reach the internal API of the admin host of the org,
+// which the Okta SDK doesn't support.
+type InternalAPI struct {
+	// URL is the URL of the admin host, e.g. https://example-admin.okta.com
+	URL string
+	// Authorization is the Authorization header of the requests
+	Authorization string
+	// Client makes the requests, it is the http client of the provider so they
+	// go through the same transports as the other API requests
+	Client *http.Client
+}
+
+func (m *APISupplement) UpdateSecurityNotificationEmails(ctx context.Context, body SecurityNotificationEmails, api InternalAPI) (*SecurityNotificationEmails, error) {
 	buff := new(bytes.Buffer)
 	encoder := json.NewEncoder(buff)
 	encoder.SetEscapeHTML(false)
@@ -26,56 +38,43 @@ func (m *APISupplement) UpdateSecurityNotificationEmails(ctx context.Context, bo
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest(http.MethodPut, url, buff)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Authorization", "SSWS "+token)
-	req.Header.Add("Content-Type", "application/json")
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-	respBody, err := ioutil.ReadAll(res.Body)
+	var emails SecurityNotificationEmails
+	err = api.do(ctx, http.MethodPut, "/api/internal/org/settings/security-notification-settings", buff, &emails)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode > http.StatusNoContent {
-		return nil, fmt.Errorf("API returned HTTP status %d, err: %s", res.StatusCode, string(respBody))
-	}
-	var threatInsightConfiguration SecurityNotificationEmails
-	err = json.Unmarshal(respBody, &threatInsightConfiguration)
+	return &emails, nil
+}
+
+func (m *APISupplement) GetSecurityNotificationEmails(ctx context.Context, api InternalAPI) (*SecurityNotificationEmails, error) {
+	var emails SecurityNotificationEmails
+	err := api.do(ctx, http.MethodGet, "/api/internal/org/settings/security-notification-settings", nil, &emails)
 	if err != nil {
 		return nil, err
 	}
-	return &threatInsightConfiguration, nil
+	return &emails, nil
 }
 
-func (m *APISupplement) GetSecurityNotificationEmails(ctx context.Context, orgName, domain, token string, client *http.Client) (*SecurityNotificationEmails, error) {
-	url := fmt.Sprintf("https://%s-admin.%s/api/internal/org/settings/security-notification-settings", orgName, domain)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// do makes a request to the internal API and decodes its response into v.
+func (api InternalAPI) do(ctx context.Context, method, path string, body io.Reader, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, api.URL+path, body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	req.Header.Add("Authorization", "SSWS "+token)
+	req.Header.Add("Authorization", api.Authorization)
 	req.Header.Add("Content-Type", "application/json")
-	res, err := client.Do(req)
+	req.Header.Add("Accept", "application/json")
+	res, err := api.Client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer res.Body.Close()
 	respBody, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if res.StatusCode > http.StatusNoContent {
-		return nil, fmt.Errorf("API returned HTTP status %d, err: %s", res.StatusCode, string(respBody))
-	}
-	var threatInsightConfiguration SecurityNotificationEmails
-	err = json.Unmarshal(respBody, &threatInsightConfiguration)
-	if err != nil {
-		return nil, err
+		return fmt.Errorf("API returned HTTP status %d, err: %s", res.StatusCode, string(respBody))
 	}
-	return &threatInsightConfiguration, nil
+	return json.Unmarshal(respBody, v)
 }
@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/okta/okta-sdk-golang/v2/okta"
+	"github.com/okta/okta-sdk-golang/v2/okta/query"
+)
+
+// ListLogEvents gets the System Log events matching the query params, e.g.
+// since, until, filter, q and sortOrder. The events are returned as they are
+// sent by the API, they have more attributes than okta.LogEvent, e.g. the
+// details of the debug context of each event type.
+func (m *APISupplement) ListLogEvents(ctx context.Context, qp *query.Params) ([]json.RawMessage, *okta.Response, error) {
+	url := "/api/v1/logs"
+	if qp != nil {
+		url += qp.String()
+	}
+	req, err := m.RequestExecutor.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var events []json.RawMessage
+	resp, err := m.RequestExecutor.Do(ctx, req, &events)
+	if err != nil {
+		return nil, resp, err
+	}
+	return events, resp, nil
+}